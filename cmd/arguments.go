@@ -26,5 +26,5 @@ func FileNameFromArguments(cmdName string) string {
 func printHelp(cmdName string) {
 	fmt.Printf("Usage: %s [OPTIONS] FILE\n", cmdName)
 	fmt.Println("Options:")
-	fmt.Println("  -h    Display this help message and exit")
+	flag.PrintDefaults()
 }
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/pascalPost/game-boy-emulator/cmd"
+	"github.com/pascalPost/game-boy-emulator/internal"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+func name(location internal.BankAddress, symbols *internal.Symbols) string {
+	if name, ok := symbols.Lookup(location); ok {
+		return fmt.Sprintf("%s (%s)", name, location)
+	}
+	return location.String()
+}
+
+func names(locations []internal.BankAddress, symbols *internal.Symbols) string {
+	if len(locations) == 0 {
+		return "-"
+	}
+	list := make([]string, len(locations))
+	for i, location := range locations {
+		list[i] = name(location, symbols)
+	}
+	return strings.Join(list, ", ")
+}
+
+func printRoutines(x *internal.CrossReference, symbols *internal.Symbols) {
+	fmt.Printf("Routines:\n")
+	for _, routine := range x.SortedRoutines() {
+		fmt.Printf("%s\n", name(routine.Entry, symbols))
+		fmt.Printf("    callers: %s\n", names(routine.Callers, symbols))
+		fmt.Printf("    callees: %s\n", names(routine.Callees, symbols))
+	}
+}
+
+func printAccesses(x *internal.CrossReference, symbols *internal.Symbols) {
+	addresses := make([]uint16, 0, len(x.Accesses))
+	for address := range x.Accesses {
+		addresses = append(addresses, address)
+	}
+	slices.Sort(addresses)
+
+	fmt.Printf("\nMemory accesses (through [HL], [BC], [DE] and [C] only if loaded in the same basic block):\n")
+	for _, address := range addresses {
+		if name, ok := symbols.LookupAddress(address); ok {
+			fmt.Printf("%s (0x%04X)\n", name, address)
+		} else {
+			fmt.Printf("0x%04X\n", address)
+		}
+		for _, access := range x.Accesses[address] {
+			kind := "read "
+			if access.Write {
+				kind = "write"
+			}
+			fmt.Printf("    %s %s\n", kind, name(access.Site, symbols))
+		}
+	}
+}
+
+func writeFile(path string, write func(file *os.File) error) {
+	file, err := os.Create(path)
+	if err != nil {
+		log.Panicf("error creating %s: %v", path, err)
+	}
+	defer file.Close()
+	if err := write(file); err != nil {
+		log.Panicf("error writing %s: %v", path, err)
+	}
+}
+
+func main() {
+	symbolsPath := flag.String("sym", "", "Load symbol names from this .sym file")
+	callGraph := flag.String("calls", "", "Write the call graph as Graphviz DOT to this file")
+	controlFlowDir := flag.String("cfg", "", "Write the control-flow graph of each routine as Graphviz DOT into this directory")
+	fileName := cmd.FileNameFromArguments("crossReference")
+
+	symbols := internal.NewSymbols()
+	if *symbolsPath != "" {
+		var err error
+		symbols, err = internal.LoadSymbols(*symbolsPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	opcodes, err := internal.ParseOpcodes()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rom, err := os.ReadFile(fileName)
+	if err != nil {
+		log.Panicf("error reading rom: %v", err)
+	}
+
+	var entryPoints []internal.BankAddress
+	for _, address := range internal.EntryPoints() {
+		entryPoints = append(entryPoints, internal.BankAddress{Address: address})
+	}
+	instructions := internal.DisassembleRecursive(rom, internal.EntryPoints(), opcodes)
+	x := internal.NewCrossReference(instructions, entryPoints)
+
+	printRoutines(x, symbols)
+	printAccesses(x, symbols)
+
+	if *callGraph != "" {
+		writeFile(*callGraph, func(file *os.File) error {
+			return x.WriteCallGraphDOT(file, symbols)
+		})
+	}
+	if *controlFlowDir != "" {
+		if err := os.MkdirAll(*controlFlowDir, 0o755); err != nil {
+			log.Panicf("error creating %s: %v", *controlFlowDir, err)
+		}
+		for _, routine := range x.SortedRoutines() {
+			fileName := fmt.Sprintf("%03X_%04X.dot", routine.Entry.Bank, routine.Entry.Address)
+			writeFile(filepath.Join(*controlFlowDir, fileName), func(file *os.File) error {
+				return routine.WriteControlFlowDOT(file, symbols)
+			})
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"github.com/pascalPost/game-boy-emulator/cmd"
 	"github.com/pascalPost/game-boy-emulator/internal"
@@ -10,23 +11,46 @@ import (
 	"os"
 )
 
-func printInstructions(data []byte, instructions []internal.Instruction, offset uint16) {
+func printInstructions(instructions []internal.Instruction, symbols *internal.Symbols) {
 	for _, instruction := range instructions {
-		for i := 0; i < int(instruction.AddressEnd-instruction.AddressStart); i++ {
-			address := instruction.AddressStart + uint16(i) + offset
-			if i == 0 {
-				fmt.Printf("0x%04X %02X %s\n", address, data[address], instruction.Line)
+		if name, ok := symbols.Lookup(instruction.Location()); ok {
+			fmt.Printf("%s:\n", name)
+		}
+		for i, b := range instruction.Bytes {
+			location := internal.BankAddress{Bank: instruction.Bank, Address: instruction.AddressStart + uint16(i)}
+			if instruction.Data {
+				// data runs of the recursive mode may be long, so they are printed byte by byte
+				fmt.Printf("%s %02X db 0x%02X\n", location, b, b)
+			} else if i == 0 {
+				fmt.Printf("%s %02X %s\n", location, b, symbols.FormatInstruction(instruction))
 			} else {
-				fmt.Printf("0x%04X %02X\n", address, data[address])
+				fmt.Printf("%s %02X\n", location, b)
 			}
 		}
 	}
 }
 
 func main() {
+	opcodesPath := flag.String("opcodes", "", "Load the opcode table from this json file instead of the built-in one")
+	recursive := flag.Bool("recursive", false, "Follow the control flow from the entry points and print unreached bytes as data")
+	rgbds := flag.Bool("rgbds", false, "Print reassemblable RGBDS source instead of a listing")
+	incbinThreshold := flag.Int("incbin", 256, "Minimum length of data blocks the RGBDS source includes from the rom with INCBIN")
+	symbolsPath := flag.String("sym", "", "Load symbol names from this .sym file")
 	fileName := cmd.FileNameFromArguments("disassembler")
 
+	symbols := internal.NewSymbols()
+	if *symbolsPath != "" {
+		var err error
+		symbols, err = internal.LoadSymbols(*symbolsPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	opcodes, err := internal.ParseOpcodes()
+	if *opcodesPath != "" {
+		opcodes, err = internal.LoadOpcodes(*opcodesPath)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -47,19 +71,38 @@ func main() {
 		slog.Error("error in reading rom")
 	}
 
+	if *rgbds {
+		var instructions []internal.Instruction
+		if *recursive {
+			instructions = internal.DisassembleRecursive(data, internal.EntryPoints(), opcodes)
+		} else {
+			instructions = internal.Disassemble(data, 0, opcodes)
+		}
+		options := internal.RGBDSOptions{ROMFile: fileName, IncbinThreshold: *incbinThreshold, Symbols: symbols}
+		if err := internal.WriteRGBDS(os.Stdout, instructions, options); err != nil {
+			log.Panicf("error writing source: %v", err)
+		}
+		return
+	}
+
+	if *recursive {
+		instructions := internal.DisassembleRecursive(data, internal.EntryPoints(), opcodes)
+		printInstructions(instructions, symbols)
+		return
+	}
+
 	fmt.Printf("Header entry point:\n")
 
-	header, err := internal.NewHeader(data)
+	_, err = internal.NewHeader(data)
 	if err != nil {
 		log.Panicf("error on reading header: %s", err)
 	}
-	entryOffset := uint16(0x0100)
-	entry := header.Raw.EntryPoint
-	instructions := internal.Disassemble(entry[:], 0, opcodes)
-	printInstructions(data, instructions, entryOffset)
+	// the entry point occupies 0x0100-0x0103, followed by the rest of the header
+	instructions := internal.Disassemble(data[:0x0104], 0x0100, opcodes)
+	printInstructions(instructions, symbols)
 
 	fmt.Printf("\n")
 	fmt.Printf("Read program:\n")
 	instructions = internal.Disassemble(data, 0x0150, opcodes)
-	printInstructions(data, instructions, 0)
+	printInstructions(instructions, symbols)
 }
@@ -1,17 +1,52 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"github.com/pascalPost/game-boy-emulator/cmd"
 	"github.com/pascalPost/game-boy-emulator/internal"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
+	wallClockRTC := flag.Bool("rtc-wall-clock", false, "Sync the cartridge real-time clock to the wall clock")
+	strictHeader := flag.Bool("strict", false, "Refuse roms with an invalid logo or checksums")
+	symbolsPath := flag.String("sym", "", "Name the traced instructions with the symbols of this .sym file")
 	fileName := cmd.FileNameFromArguments("emulator")
+
+	var options []internal.CartridgeOption
+	if *wallClockRTC {
+		options = append(options, internal.WithWallClockRTC())
+	}
+	if *strictHeader {
+		options = append(options, internal.WithStrictHeader())
+	}
+
 	gb := internal.NewGameBoy()
-	err := gb.LoadCartridge(fileName)
+	symbols := internal.NewSymbols()
+	if *symbolsPath != "" {
+		var err error
+		symbols, err = internal.LoadSymbols(*symbolsPath)
+		if err != nil {
+			log.Panicf("error loading symbols: %v", err)
+		}
+	}
+	gb.SetSymbols(symbols)
+
+	err := gb.LoadCartridge(fileName, options...)
 	if err != nil {
 		log.Panicf("error loading cartridge: %v", err)
 	}
-	gb.Run()
+
+	// stop cleanly on interrupt, so the save RAM is flushed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err = gb.Run(ctx)
+	if err != nil {
+		log.Panicf("error writing save: %v", err)
+	}
 }
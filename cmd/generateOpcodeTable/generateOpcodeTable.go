@@ -0,0 +1,272 @@
+// generateOpcodeTable reads Opcodes.json and writes the opcode tables the cpu uses to dispatch instructions. It is run
+// by go generate in the internal package. It only depends on the opcodes package, so it still builds when a stale
+// opcodeTable.go keeps the internal package from compiling.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"github.com/pascalPost/game-boy-emulator/internal/opcodes"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+)
+
+func is16Bit(operand opcodes.Operand) bool {
+	if !operand.Immediate {
+		return false
+	}
+	switch operand.Name {
+	case "BC", "DE", "HL", "SP", "AF", "n16":
+		return true
+	}
+	return false
+}
+
+func operand8(operand opcodes.Operand) string {
+	if operand.Immediate {
+		switch operand.Name {
+		case "A", "B", "C", "D", "E", "H", "L":
+			return "operand" + operand.Name
+		case "n8":
+			return "operandImmediate8"
+		}
+	} else {
+		switch operand.Name {
+		case "HL":
+			if operand.Increment {
+				return "operandIndirectHLIncrement"
+			}
+			if operand.Decrement {
+				return "operandIndirectHLDecrement"
+			}
+			return "operandIndirectHL"
+		case "BC", "DE":
+			return "operandIndirect" + operand.Name
+		case "C":
+			return "operandIndirectHighC"
+		case "a8":
+			return "operandIndirectHighImmediate8"
+		case "a16":
+			return "operandIndirectImmediate16"
+		}
+	}
+	log.Fatalf("unknown 8-bit operand: %+v", operand)
+	return ""
+}
+
+func operand16(operand opcodes.Operand) string {
+	if operand.Immediate {
+		switch operand.Name {
+		case "BC", "DE", "HL", "SP", "AF":
+			return "operand" + operand.Name
+		case "n16":
+			return "operandImmediate16"
+		}
+	} else if operand.Name == "a16" {
+		return "operandIndirectWord16"
+	}
+	log.Fatalf("unknown 16-bit operand: %+v", operand)
+	return ""
+}
+
+func condition(operands []opcodes.Operand, n int) string {
+	// the condition is the first of n operands, without it the instruction is unconditional
+	if len(operands) < n {
+		return "conditionAlways"
+	}
+	switch operands[0].Name {
+	case "NZ", "Z", "NC", "C":
+		return "condition" + operands[0].Name
+	}
+	log.Fatalf("unknown condition: %+v", operands[0])
+	return ""
+}
+
+var shiftImpl = map[string]string{
+	"RLCA": "rotateLeftCircularImpl",
+	"RRCA": "rotateRightCircularImpl",
+	"RLA":  "rotateLeftImpl",
+	"RRA":  "rotateRightImpl",
+	"RLC":  "rotateLeftCircularImpl",
+	"RRC":  "rotateRightCircularImpl",
+	"RL":   "rotateLeftImpl",
+	"RR":   "rotateRightImpl",
+	"SLA":  "shiftLeftArithmeticImpl",
+	"SRA":  "shiftRightArithmeticImpl",
+	"SWAP": "swapImpl",
+	"SRL":  "shiftRightLogicalImpl",
+}
+
+// handler returns the call of the operand-generic handler implementing the opcode. An empty string is returned for
+// the 0xCB prefix, which is resolved by the cpu before dispatching.
+func handler(code byte, opcode opcodes.Opcode) string {
+	operands := opcode.Operands
+	mnemonic := opcode.Mnemonic
+
+	switch mnemonic {
+	case "NOP", "DAA", "CPL", "SCF", "CCF", "DI", "EI":
+		return fmt.Sprintf("cpu.%s()", strings.ToLower(mnemonic))
+	case "HALT", "STOP", "RETI":
+		return fmt.Sprintf("cpu.%s(bus)", strings.ToLower(mnemonic))
+	case "PREFIX":
+		return ""
+	case "LD", "LDH":
+		if len(operands) == 3 {
+			return "cpu.ldHLStackPointerOffset(bus)"
+		}
+		if is16Bit(operands[0]) || is16Bit(operands[1]) {
+			return fmt.Sprintf("cpu.ld16(bus, %s, %s)", operand16(operands[0]), operand16(operands[1]))
+		}
+		return fmt.Sprintf("cpu.ld8(bus, %s, %s)", operand8(operands[0]), operand8(operands[1]))
+	case "INC", "DEC":
+		if is16Bit(operands[0]) {
+			return fmt.Sprintf("cpu.%s16(%s)", strings.ToLower(mnemonic), operand16(operands[0]))
+		}
+		return fmt.Sprintf("cpu.%s8(bus, %s)", strings.ToLower(mnemonic), operand8(operands[0]))
+	case "ADD":
+		switch {
+		case operands[0].Name == "HL":
+			return fmt.Sprintf("cpu.addHL(%s)", operand16(operands[1]))
+		case operands[0].Name == "SP":
+			return "cpu.addStackPointer(bus)"
+		}
+		return fmt.Sprintf("cpu.add(bus, %s)", operand8(operands[1]))
+	case "ADC", "SUB", "SBC", "AND", "XOR", "OR", "CP":
+		return fmt.Sprintf("cpu.%s(bus, %s)", strings.ToLower(mnemonic), operand8(operands[len(operands)-1]))
+	case "RLCA", "RRCA", "RLA", "RRA":
+		return fmt.Sprintf("cpu.shift(bus, operandA, %s)", shiftImpl[mnemonic])
+	case "RLC", "RRC", "RL", "RR", "SLA", "SRA", "SWAP", "SRL":
+		return fmt.Sprintf("cpu.shift(bus, %s, %s)", operand8(operands[0]), shiftImpl[mnemonic])
+	case "BIT", "RES", "SET":
+		return fmt.Sprintf("cpu.%s(bus, %s, %s)", strings.ToLower(mnemonic), operands[0].Name, operand8(operands[1]))
+	case "JP":
+		if operands[0].Name == "HL" {
+			return "cpu.jpHL()"
+		}
+		return fmt.Sprintf("cpu.jp(bus, %s)", condition(operands, 2))
+	case "JR", "CALL":
+		return fmt.Sprintf("cpu.%s(bus, %s)", strings.ToLower(mnemonic), condition(operands, 2))
+	case "RET":
+		return fmt.Sprintf("cpu.ret(bus, %s)", condition(operands, 1))
+	case "RST":
+		return fmt.Sprintf("cpu.rst(bus, 0x%s)", strings.TrimPrefix(operands[0].Name, "$"))
+	case "PUSH", "POP":
+		return fmt.Sprintf("cpu.%s16(bus, %s)", strings.ToLower(mnemonic), operand16(operands[0]))
+	}
+
+	if strings.HasPrefix(mnemonic, "ILLEGAL_") {
+		return fmt.Sprintf("cpu.illegal(0x%02X)", code)
+	}
+
+	log.Fatalf("unknown mnemonic: %s", mnemonic)
+	return ""
+}
+
+// isBranch reports whether the handler returns if a (conditional) branch was taken.
+func isBranch(call string) bool {
+	for _, prefix := range []string{"cpu.jp(", "cpu.jr(", "cpu.call(", "cpu.ret("} {
+		if strings.HasPrefix(call, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func name(opcode opcodes.Opcode) string {
+	var b strings.Builder
+	b.WriteString(opcode.Mnemonic)
+	separator := " "
+	for _, operand := range opcode.Operands {
+		b.WriteString(separator)
+		separator = ", "
+
+		text := operand.Name
+		if operand.Increment {
+			text += "+"
+			if operand.Immediate {
+				// SP+e8
+				separator = ""
+			}
+		}
+		if operand.Decrement {
+			text += "-"
+		}
+		if !operand.Immediate {
+			text = "[" + text + "]"
+		}
+		b.WriteString(text)
+	}
+	return b.String()
+}
+
+func flagMask(flags opcodes.Flags, value string) string {
+	mask := 0
+	for i, flag := range []string{flags.Z, flags.N, flags.H, flags.C} {
+		if flag == value {
+			mask |= 0b1000 >> i
+		}
+	}
+	return fmt.Sprintf("0b%04b_0000", mask)
+}
+
+func writeTable(b *bytes.Buffer, tableName string, table map[opcodes.ByteKey]opcodes.Opcode) {
+	fmt.Fprintf(b, "var %s = [256]instruction{\n", tableName)
+	for i := 0; i < 256; i++ {
+		code := byte(i)
+		opcode, ok := table[opcodes.ByteKey{Value: code}]
+		if !ok {
+			log.Fatalf("%s: missing opcode 0x%02X", tableName, code)
+		}
+
+		cycles := [2]int{opcode.Cycles[0], opcode.Cycles[0]}
+		if len(opcode.Cycles) > 1 {
+			cycles[1] = opcode.Cycles[1]
+		}
+
+		exec := "nil"
+		if call := handler(code, opcode); call != "" {
+			if isBranch(call) {
+				exec = fmt.Sprintf("func(cpu *cpu, bus *Bus) bool { return %s }", call)
+			} else {
+				exec = fmt.Sprintf("func(cpu *cpu, bus *Bus) bool { %s; return true }", call)
+			}
+		}
+
+		fmt.Fprintf(b, "0x%02X: {name: %q, bytes: %d, cycles: [2]int{%d, %d}, flags: flagEffects{keep: %s, reset: %s, set: %s}, exec: %s},\n",
+			code, name(opcode), opcode.Bytes, cycles[0], cycles[1],
+			flagMask(opcode.Flags, "-"), flagMask(opcode.Flags, "0"), flagMask(opcode.Flags, "1"), exec)
+	}
+	fmt.Fprintf(b, "}\n\n")
+}
+
+func main() {
+	output := flag.String("o", "opcodeTable.go", "Output file")
+	flag.Parse()
+
+	list, err := opcodes.Parse()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by generateOpcodeTable from Opcodes.json; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package internal\n\n")
+	fmt.Fprintf(&b, "// unprefixedInstructions is indexed by the opcode. The entry of the 0xCB prefix has no handler, the cpu\n")
+	fmt.Fprintf(&b, "// dispatches the following byte through prefixedInstructions instead.\n")
+	writeTable(&b, "unprefixedInstructions", list.UnPrefixed)
+	fmt.Fprintf(&b, "// prefixedInstructions is indexed by the byte following the 0xCB prefix.\n")
+	writeTable(&b, "prefixedInstructions", list.CbPrefixed)
+
+	source, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = os.WriteFile(*output, source, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/pascalPost/game-boy-emulator/cmd"
+	"github.com/pascalPost/game-boy-emulator/internal"
+	"log"
+	"log/slog"
+	"os"
+)
+
+// unset marks numeric flags that were not given.
+const unset = -1
+
+func parseCGBSupport(value string) (internal.CGBSupport, error) {
+	switch value {
+	case "none":
+		return internal.CGBNone, nil
+	case "compatible":
+		return internal.CGBCompatible, nil
+	case "only":
+		return internal.CGBOnly, nil
+	}
+	return 0, fmt.Errorf("unknown CGB support %q (none, compatible or only)", value)
+}
+
+func byteFlag(name string, value int) (byte, bool) {
+	if value == unset {
+		return 0, false
+	}
+	if value < 0 || value > 0xFF {
+		log.Panicf("-%s must be a byte, got %d", name, value)
+	}
+	return byte(value), true
+}
+
+func main() {
+	output := flag.String("o", "", "Write the fixed rom to this file (required)")
+	fixLogo := flag.Bool("logo", false, "Store the Nintendo logo")
+	fixChecksums := flag.Bool("checksums", false, "Recompute the header and global checksums")
+	title := flag.String("title", "", "Set the title")
+	cgb := flag.String("cgb", "", "Set the CGB flag: none, compatible or only")
+	sgb := flag.Bool("sgb", false, "Set the SGB flag")
+	mapper := flag.Int("mapper", unset, "Set the cartridge type, e.g. 0x1B")
+	ram := flag.Int("ram", unset, "Set the RAM size code, e.g. 0x02")
+	version := flag.Int("version", unset, "Set the mask ROM version number")
+	pad := flag.Bool("pad", false, "Pad the rom with 0xFF to a power of two number of banks and set the ROM size")
+	fileName := cmd.FileNameFromArguments("romFix")
+
+	if *output == "" {
+		log.Panicf("no output file given (-o)")
+	}
+	if *output == fileName {
+		log.Panicf("the output file must differ from the input rom")
+	}
+
+	rom, err := os.ReadFile(fileName)
+	if err != nil {
+		log.Panicf("error reading rom: %v", err)
+	}
+
+	if *pad {
+		rom = internal.PadROM(rom)
+	}
+
+	header, err := internal.NewHeader(rom)
+	if err != nil {
+		log.Panicf("error parsing header: %v", err)
+	}
+
+	if *pad {
+		if err := header.SetRomSize(len(rom)); err != nil {
+			log.Panicf("error setting rom size: %v", err)
+		}
+	}
+	if *fixLogo {
+		header.FixLogo()
+	}
+	// the CGB flag limits the length of the title, so it is set first
+	if *cgb != "" {
+		support, err := parseCGBSupport(*cgb)
+		if err != nil {
+			log.Panic(err)
+		}
+		header.SetCGBSupport(support)
+	}
+	if *title != "" {
+		if err := header.SetTitle(*title); err != nil {
+			log.Panic(err)
+		}
+	}
+	if *sgb {
+		header.SetSGBSupport(true)
+		if !header.SGBSupport() {
+			slog.Warn("the SGB flag is ignored unless the old licensee code is 0x33")
+		}
+	}
+	if code, ok := byteFlag("mapper", *mapper); ok {
+		header.Raw.CartridgeType = code
+	}
+	if code, ok := byteFlag("ram", *ram); ok {
+		header.Raw.RamSize = code
+	}
+	if number, ok := byteFlag("version", *version); ok {
+		header.Raw.MaskRomVersionNumber = number
+	}
+
+	if *fixChecksums {
+		header.FixHeaderChecksum()
+	}
+	header.CopyTo(rom)
+	if *fixChecksums {
+		internal.FixGlobalChecksum(rom)
+	}
+
+	err = os.WriteFile(*output, rom, 0o644)
+	if err != nil {
+		log.Panicf("error writing rom: %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/pascalPost/game-boy-emulator/cmd"
+	"github.com/pascalPost/game-boy-emulator/internal"
+	"log"
+	"os"
+)
+
+type romInfo struct {
+	File             string   `json:"file"`
+	Title            string   `json:"title"`
+	ManufacturerCode string   `json:"manufacturerCode,omitempty"`
+	CartridgeType    string   `json:"cartridgeType"`
+	CartridgeCode    byte     `json:"cartridgeCode"`
+	RomSize          string   `json:"romSize"`
+	RomBanks         int      `json:"romBanks"`
+	RamSize          string   `json:"ramSize"`
+	RamBanks         int      `json:"ramBanks"`
+	Licensee         string   `json:"licensee"`
+	Destination      string   `json:"destination"`
+	Version          byte     `json:"version"`
+	CGB              string   `json:"cgb"`
+	SGB              bool     `json:"sgb"`
+	ValidLogo        bool     `json:"validLogo"`
+	HeaderChecksum   checksum `json:"headerChecksum"`
+	GlobalChecksum   checksum `json:"globalChecksum"`
+	FileSize         int      `json:"fileSize"`
+	HeaderFileSize   int      `json:"headerFileSize"`
+	FileSizeValid    bool     `json:"fileSizeValid"`
+}
+
+type checksum struct {
+	Expected uint16 `json:"expected"`
+	Actual   uint16 `json:"actual"`
+	Valid    bool   `json:"valid"`
+}
+
+func newChecksum(expected, actual uint16) checksum {
+	return checksum{expected, actual, expected == actual}
+}
+
+func newRomInfo(fileName string, rom []byte) (romInfo, error) {
+	header, err := internal.NewHeader(rom)
+	if err != nil {
+		return romInfo{}, fmt.Errorf("rom too small for a header: %w", err)
+	}
+
+	romSize := header.RomSize()
+	ramSize := header.RamSize()
+	headerFileSize := romSize.NumberOfRomBanks * 0x4000
+	return romInfo{
+		File:             fileName,
+		Title:            header.Title(),
+		ManufacturerCode: header.ManufacturerCode(),
+		CartridgeType:    header.CartridgeType(),
+		CartridgeCode:    header.Raw.CartridgeType,
+		RomSize:          romSize.RomSize,
+		RomBanks:         romSize.NumberOfRomBanks,
+		RamSize:          ramSize.RamSize,
+		RamBanks:         ramSize.NumberOfRamBanks,
+		Licensee:         header.Licensee(),
+		Destination:      header.Destination(),
+		Version:          header.Version(),
+		CGB:              header.CGBSupport().String(),
+		SGB:              header.SGBSupport(),
+		ValidLogo:        header.ValidLogo(),
+		HeaderChecksum:   newChecksum(uint16(header.ComputeHeaderChecksum()), uint16(header.Raw.HeaderChecksum)),
+		GlobalChecksum:   newChecksum(internal.ComputeGlobalChecksum(rom), header.GlobalChecksum()),
+		FileSize:         len(rom),
+		HeaderFileSize:   headerFileSize,
+		FileSizeValid:    len(rom) == headerFileSize,
+	}, nil
+}
+
+func validString(valid bool) string {
+	if valid {
+		return "ok"
+	}
+	return "INVALID"
+}
+
+func printInfo(info romInfo) {
+	fmt.Printf("File:              %s\n", info.File)
+	fmt.Printf("Title:             %s\n", info.Title)
+	if info.ManufacturerCode != "" {
+		fmt.Printf("Manufacturer code: %s\n", info.ManufacturerCode)
+	}
+	fmt.Printf("Cartridge type:    %s (0x%02X)\n", info.CartridgeType, info.CartridgeCode)
+	fmt.Printf("ROM size:          %s (%d banks)\n", info.RomSize, info.RomBanks)
+	fmt.Printf("RAM size:          %s (%d banks)\n", info.RamSize, info.RamBanks)
+	if info.Licensee == "" {
+		info.Licensee = "unknown"
+	}
+	fmt.Printf("Licensee:          %s\n", info.Licensee)
+	fmt.Printf("Destination:       %s\n", info.Destination)
+	fmt.Printf("Version:           %d\n", info.Version)
+	fmt.Printf("CGB:               %s\n", info.CGB)
+	fmt.Printf("SGB:               %t\n", info.SGB)
+	fmt.Printf("Nintendo logo:     %s\n", validString(info.ValidLogo))
+	fmt.Printf("Header checksum:   %s (expected 0x%02X, found 0x%02X)\n",
+		validString(info.HeaderChecksum.Valid), info.HeaderChecksum.Expected, info.HeaderChecksum.Actual)
+	fmt.Printf("Global checksum:   %s (expected 0x%04X, found 0x%04X)\n",
+		validString(info.GlobalChecksum.Valid), info.GlobalChecksum.Expected, info.GlobalChecksum.Actual)
+	fmt.Printf("File size:         %d bytes, header declares %d bytes (%s)\n",
+		info.FileSize, info.HeaderFileSize, validString(info.FileSizeValid))
+}
+
+func main() {
+	jsonOutput := flag.Bool("json", false, "Print the report as JSON")
+	fileName := cmd.FileNameFromArguments("romInfo")
+
+	rom, err := os.ReadFile(fileName)
+	if err != nil {
+		log.Panicf("error reading rom: %v", err)
+	}
+
+	info, err := newRomInfo(fileName, rom)
+	if err != nil {
+		log.Panicf("error parsing header: %v", err)
+	}
+
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(info); err != nil {
+			log.Panicf("error encoding json: %v", err)
+		}
+		return
+	}
+	printInfo(info)
+}
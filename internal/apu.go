@@ -0,0 +1,20 @@
+package internal
+
+// frameSequencerPeriod is the number of T-cycles between two steps of the frame sequencer (512 Hz).
+const frameSequencerPeriod = clockSpeed / 512
+
+// apu keeps track of the timing of the audio processing unit. The frame sequencer clocks length counters (steps 0, 2,
+// 4, 6), the sweep (steps 2, 6) and the volume envelopes (step 7).
+type apu struct {
+	cycles int
+	step   uint8 // current step of the frame sequencer (0-7)
+}
+
+// tick advances the apu by the given number of T-cycles.
+func (a *apu) tick(cycles int) {
+	a.cycles += cycles
+	for a.cycles >= frameSequencerPeriod {
+		a.cycles -= frameSequencerPeriod
+		a.step = (a.step + 1) % 8
+	}
+}
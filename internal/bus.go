@@ -0,0 +1,200 @@
+package internal
+
+// Region is an area of the Game Boy address space.
+type Region int
+
+const (
+	RegionROM         Region = iota // 0x0000-0x7FFF cartridge ROM
+	RegionVRAM                      // 0x8000-0x9FFF video RAM
+	RegionExternalRAM               // 0xA000-0xBFFF cartridge RAM
+	RegionWRAM                      // 0xC000-0xDFFF work RAM
+	RegionEcho                      // 0xE000-0xFDFF mirror of 0xC000-0xDDFF
+	RegionOAM                       // 0xFE00-0xFE9F object attribute memory
+	RegionUnusable                  // 0xFEA0-0xFEFF not usable
+	RegionIO                        // 0xFF00-0xFF7F I/O registers
+	RegionHRAM                      // 0xFF80-0xFFFE high RAM
+	RegionIE                        // 0xFFFF interrupt enable register
+	regionCount
+)
+
+var regionNames = [regionCount]string{"ROM", "VRAM", "External RAM", "WRAM", "Echo RAM", "OAM", "Unusable", "I/O", "HRAM", "IE"}
+
+func (r Region) String() string {
+	return regionNames[r]
+}
+
+// RegionOf returns the region the address belongs to.
+func RegionOf(address uint16) Region {
+	switch {
+	case address < 0x8000:
+		return RegionROM
+	case address < 0xA000:
+		return RegionVRAM
+	case address < 0xC000:
+		return RegionExternalRAM
+	case address < 0xE000:
+		return RegionWRAM
+	case address < 0xFE00:
+		return RegionEcho
+	case address < 0xFEA0:
+		return RegionOAM
+	case address < 0xFF00:
+		return RegionUnusable
+	case address < 0xFF80:
+		return RegionIO
+	case address < 0xFFFF:
+		return RegionHRAM
+	default:
+		return RegionIE
+	}
+}
+
+// openBus is the value read from addresses no device responds to.
+const openBus uint8 = 0xFF
+
+// AccessHook is called after every access to the region it is registered for. For reads value is the value read,
+// for writes the value written (even if the write was ignored, e.g. to ROM).
+type AccessHook func(address uint16, value uint8, write bool)
+
+// Bus routes the reads and writes of the cpu to the memory and devices of the Game Boy address map.
+type Bus struct {
+	cartridge Cartridge
+
+	vram [0x2000]byte
+	wram [0x2000]byte
+	oam  [0xA0]byte
+	io   [0x80]byte // io holds the I/O registers not handled by a device
+	hram [0x7F]byte
+
+	timer      *timer
+	ppu        *ppu
+	interrupts *interrupts
+	speed      *speed // speed is only set in CGB mode
+
+	hooks [regionCount][]AccessHook
+}
+
+// Hook registers a hook that is called on every access to the region.
+func (b *Bus) Hook(region Region, hook AccessHook) {
+	b.hooks[region] = append(b.hooks[region], hook)
+}
+
+func (b *Bus) callHooks(region Region, address uint16, value uint8, write bool) {
+	for _, hook := range b.hooks[region] {
+		hook(address, value, write)
+	}
+}
+
+func (b *Bus) read(address uint16) uint8 {
+	region := RegionOf(address)
+	value := b.readRegion(region, address)
+	b.callHooks(region, address, value, false)
+	return value
+}
+
+func (b *Bus) write(address uint16, value uint8) {
+	region := RegionOf(address)
+	b.writeRegion(region, address, value)
+	b.callHooks(region, address, value, true)
+}
+
+func (b *Bus) readRegion(region Region, address uint16) uint8 {
+	switch region {
+	case RegionROM:
+		if b.cartridge == nil {
+			return openBus
+		}
+		return b.cartridge.ReadROM(address)
+	case RegionVRAM:
+		return b.vram[address-0x8000]
+	case RegionExternalRAM:
+		if b.cartridge == nil {
+			return openBus
+		}
+		return b.cartridge.ReadRAM(address)
+	case RegionWRAM:
+		return b.wram[address-0xC000]
+	case RegionEcho:
+		return b.wram[address-0xE000]
+	case RegionOAM:
+		return b.oam[address-0xFE00]
+	case RegionUnusable:
+		// reads return 0x00 on the DMG
+		return 0x00
+	case RegionIO:
+		return b.readIO(address)
+	case RegionHRAM:
+		return b.hram[address-0xFF80]
+	default:
+		if b.interrupts == nil {
+			return openBus
+		}
+		return b.interrupts.read(address)
+	}
+}
+
+func (b *Bus) writeRegion(region Region, address uint16, value uint8) {
+	switch region {
+	case RegionROM:
+		// ROM is read-only, writes go to the registers of the memory bank controller
+		if b.cartridge != nil {
+			b.cartridge.WriteROM(address, value)
+		}
+	case RegionExternalRAM:
+		if b.cartridge != nil {
+			b.cartridge.WriteRAM(address, value)
+		}
+	case RegionVRAM:
+		b.vram[address-0x8000] = value
+	case RegionWRAM:
+		b.wram[address-0xC000] = value
+	case RegionEcho:
+		b.wram[address-0xE000] = value
+	case RegionOAM:
+		b.oam[address-0xFE00] = value
+	case RegionUnusable:
+		// writes are ignored
+	case RegionIO:
+		b.writeIO(address, value)
+	case RegionHRAM:
+		b.hram[address-0xFF80] = value
+	default:
+		if b.interrupts != nil {
+			b.interrupts.write(address, value)
+		}
+	}
+}
+
+func (b *Bus) readIO(address uint16) uint8 {
+	switch {
+	case b.timer != nil && address >= divAddress && address <= tacAddress:
+		return b.timer.read(address)
+	case b.ppu != nil && address == lyAddress:
+		return b.ppu.ly
+	case b.interrupts != nil && address == interruptFlagAddress:
+		return b.interrupts.read(address)
+	case address == key1Address:
+		if b.speed == nil {
+			return openBus
+		}
+		return b.speed.read()
+	}
+	return b.io[address-0xFF00]
+}
+
+func (b *Bus) writeIO(address uint16, value uint8) {
+	switch {
+	case b.timer != nil && address >= divAddress && address <= tacAddress:
+		b.timer.write(address, value)
+	case b.ppu != nil && address == lyAddress:
+		// LY is read-only
+	case b.interrupts != nil && address == interruptFlagAddress:
+		b.interrupts.write(address, value)
+	case address == key1Address:
+		if b.speed != nil {
+			b.speed.write(value)
+		}
+	default:
+		b.io[address-0xFF00] = value
+	}
+}
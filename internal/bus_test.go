@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestRegionOf(t *testing.T) {
+	assert.Equal(t, RegionROM, RegionOf(0x7FFF))
+	assert.Equal(t, RegionVRAM, RegionOf(0x8000))
+	assert.Equal(t, RegionExternalRAM, RegionOf(0xBFFF))
+	assert.Equal(t, RegionWRAM, RegionOf(0xC000))
+	assert.Equal(t, RegionEcho, RegionOf(0xFDFF))
+	assert.Equal(t, RegionOAM, RegionOf(0xFE9F))
+	assert.Equal(t, RegionUnusable, RegionOf(0xFEA0))
+	assert.Equal(t, RegionIO, RegionOf(0xFF7F))
+	assert.Equal(t, RegionHRAM, RegionOf(0xFFFE))
+	assert.Equal(t, RegionIE, RegionOf(0xFFFF))
+}
+
+func TestBusReadOnlyROM(t *testing.T) {
+	gb := NewGameBoy()
+	rom := make([]byte, 2*romBankSize)
+	rom[0x1234] = 0x42
+	gb.bus.cartridge = &romOnly{rom: rom}
+
+	gb.bus.write(0x1234, 0x00)
+	assert.Equal(t, uint8(0x42), gb.bus.read(0x1234))
+}
+
+func TestBusEchoRAM(t *testing.T) {
+	gb := NewGameBoy()
+	gb.bus.write(0xC123, 0x42)
+	assert.Equal(t, uint8(0x42), gb.bus.read(0xE123))
+	gb.bus.write(0xFDFF, 0x24)
+	assert.Equal(t, uint8(0x24), gb.bus.read(0xDDFF))
+}
+
+func TestBusOpenBus(t *testing.T) {
+	gb := NewGameBoy()
+	assert.Equal(t, openBus, gb.bus.read(0x0100))
+	gb.bus.write(0xA000, 0x42)
+	assert.Equal(t, openBus, gb.bus.read(0xA000))
+	assert.Equal(t, openBus, gb.bus.read(key1Address))
+}
+
+func TestBusInterruptEnable(t *testing.T) {
+	gb := NewGameBoy()
+	gb.bus.write(0xFFFF, 0x1F)
+	assert.Equal(t, uint8(0x1F), gb.bus.read(0xFFFF))
+	assert.Equal(t, uint8(0x1F), gb.interrupts.enable)
+}
+
+func TestBusHooks(t *testing.T) {
+	gb := NewGameBoy()
+	var accesses []uint16
+	gb.Bus().Hook(RegionHRAM, func(address uint16, value uint8, write bool) {
+		assert.Equal(t, uint8(0x42), value)
+		accesses = append(accesses, address)
+	})
+
+	gb.bus.write(0xFF80, 0x42)
+	gb.bus.read(0xFF80)
+	gb.bus.write(0xC000, 0x42)
+
+	assert.Equal(t, []uint16{0xFF80, 0xFF80}, accesses)
+}
+
+func TestBusHooksInstructionFetch(t *testing.T) {
+	// tracing reads the instruction bytes again, which must not be reported to the hooks
+	logger := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer slog.SetDefault(logger)
+
+	gb := newTestGameBoy([]byte{0x3E, 0x42}) // LD A, 0x42
+	reads := 0
+	gb.Bus().Hook(RegionROM, func(address uint16, value uint8, write bool) {
+		reads++
+	})
+	gb.cpu.runInstruction(&gb.bus)
+	assert.Equal(t, 2, reads)
+}
@@ -0,0 +1,214 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	romBankSize = 0x4000
+	ramBankSize = 0x2000
+
+	cartridgeTypeAddress = 0x0147
+	ramSizeAddress       = 0x0149
+)
+
+// Cartridge is the hardware of a game cartridge. The ROM is mapped to 0x0000-0x7FFF, writes to this area go to the
+// registers of the memory bank controller (MBC). The external RAM is mapped to 0xA000-0xBFFF.
+type Cartridge interface {
+	ReadROM(address uint16) uint8
+	WriteROM(address uint16, value uint8)
+	ReadRAM(address uint16) uint8
+	WriteRAM(address uint16, value uint8)
+}
+
+// cartridgeClock is implemented by cartridges with hardware driven by the emulated time, e.g. a real-time clock.
+type cartridgeClock interface {
+	// tick advances the cartridge by the given number of T-cycles in normal speed.
+	tick(cycles int)
+}
+
+// rumbleCartridge is implemented by cartridges with a rumble motor.
+type rumbleCartridge interface {
+	// setRumbleHandler sets the function called whenever the motor is switched on or off.
+	setRumbleHandler(handler func(on bool))
+}
+
+// bankedCartridge is implemented by cartridges that know the ROM bank mapped to 0x4000-0x7FFF.
+type bankedCartridge interface {
+	// mappedROMBank returns the bank of the rom file mapped to 0x4000-0x7FFF.
+	mappedROMBank() int
+}
+
+// tiltCartridge is implemented by cartridges with an accelerometer.
+type tiltCartridge interface {
+	setTilt(x, y float64)
+}
+
+type cartridgeOptions struct {
+	wallClockRTC bool
+	strictHeader bool
+	now          func() time.Time
+}
+
+func newCartridgeOptions(options []CartridgeOption) cartridgeOptions {
+	config := cartridgeOptions{now: time.Now}
+	for _, option := range options {
+		option(&config)
+	}
+	return config
+}
+
+// CartridgeOption configures the cartridge created by NewCartridge.
+type CartridgeOption func(options *cartridgeOptions)
+
+// WithWallClockRTC lets the real-time clock of the cartridge follow the wall clock of the host instead of the emulated
+// time.
+func WithWallClockRTC() CartridgeOption {
+	return func(options *cartridgeOptions) {
+		options.wallClockRTC = true
+	}
+}
+
+// WithStrictHeader makes GameBoy.LoadCartridge fail for roms with an invalid logo or checksums instead of logging
+// warnings.
+func WithStrictHeader() CartridgeOption {
+	return func(options *cartridgeOptions) {
+		options.strictHeader = true
+	}
+}
+
+// NewCartridge creates the memory bank controller selected by the cartridge type in the header (0x0147).
+func NewCartridge(rom []byte, options ...CartridgeOption) (Cartridge, error) {
+	config := newCartridgeOptions(options)
+
+	if len(rom) <= ramSizeAddress {
+		return nil, fmt.Errorf("rom too small for a cartridge header: %d bytes", len(rom))
+	}
+
+	rom = PadROM(rom)
+	if isMMM01(rom) {
+		menu := rom[len(rom)-mmm01MenuSize:]
+		return newMMM01(rom, make([]byte, ramSize(menu[ramSizeAddress]))), nil
+	}
+
+	ram := make([]byte, ramSize(rom[ramSizeAddress]))
+
+	switch cartridgeType := rom[cartridgeTypeAddress]; cartridgeType {
+	case 0x00, 0x08, 0x09:
+		return &romOnly{rom: rom, ram: ram}, nil
+	case 0x01, 0x02, 0x03:
+		return newMBC1(rom, ram), nil
+	case 0x05, 0x06:
+		return newMBC2(rom), nil
+	case 0x0B, 0x0C, 0x0D:
+		// dumps with the menu header at the start of the rom
+		return newMMM01(rom, ram), nil
+	case 0x0F, 0x10:
+		return newMBC3(rom, ram, newRTC(config.wallClockRTC, config.now)), nil
+	case 0x11, 0x12, 0x13:
+		return newMBC3(rom, ram, nil), nil
+	case 0x19, 0x1A, 0x1B:
+		return newMBC5(rom, ram, false), nil
+	case 0x1C, 0x1D, 0x1E:
+		return newMBC5(rom, ram, true), nil
+	case 0x20:
+		return newMBC6(rom), nil
+	case 0x22:
+		return newMBC7(rom), nil
+	case 0xFD:
+		return newTAMA5(rom), nil
+	case 0xFE:
+		return newHuC3(rom, ram, config.now), nil
+	case 0xFF:
+		return newHuC1(rom, ram), nil
+	default:
+		return nil, fmt.Errorf("unsupported cartridge type 0x%02X (%s)", cartridgeType, cartridgeTypeNames[cartridgeType])
+	}
+}
+
+// PadROM pads the rom to a power of two number of banks (at least two), so bank numbers can be masked.
+func PadROM(rom []byte) []byte {
+	size := 2 * romBankSize
+	for size < len(rom) {
+		size *= 2
+	}
+	if size == len(rom) {
+		return rom
+	}
+	padded := make([]byte, size)
+	copy(padded, rom)
+	for i := len(rom); i < size; i++ {
+		padded[i] = openBus
+	}
+	return padded
+}
+
+// ramSize returns the size of the external RAM in bytes for the RAM size code of the header (0x0149).
+func ramSize(code byte) int {
+	// https://gbdev.io/pandocs/The_Cartridge_Header.html#0149--ram-size
+	switch code {
+	case 0x02:
+		return 8 * 1024
+	case 0x03:
+		return 32 * 1024
+	case 0x04:
+		return 128 * 1024
+	case 0x05:
+		return 64 * 1024
+	default:
+		return 0
+	}
+}
+
+// romBank returns the byte at the offset within the ROM bank. Bank numbers beyond the ROM size wrap around.
+func romBank(rom []byte, bank int, offset uint16) uint8 {
+	return rom[wrapROMBank(rom, bank)*romBankSize+int(offset)]
+}
+
+// wrapROMBank returns the bank of the rom file a bank number selects.
+func wrapROMBank(rom []byte, bank int) int {
+	return bank % (len(rom) / romBankSize)
+}
+
+// ramIndex returns the index into the external RAM for the offset within the RAM bank. Bank numbers beyond the RAM
+// size wrap around, RAM smaller than a bank is mirrored.
+func ramIndex(ram []byte, bank int, offset uint16) int {
+	return (bank*ramBankSize + int(offset)) % len(ram)
+}
+
+// romOnly is a cartridge without memory bank controller, optionally with up to 8 KiB RAM.
+type romOnly struct {
+	rom []byte
+	ram []byte
+}
+
+func (c *romOnly) saveRAM() []byte {
+	return c.ram
+}
+
+func (c *romOnly) mappedROMBank() int {
+	return 1
+}
+
+func (c *romOnly) ReadROM(address uint16) uint8 {
+	return c.rom[address]
+}
+
+func (c *romOnly) WriteROM(address uint16, value uint8) {
+	// no registers
+}
+
+func (c *romOnly) ReadRAM(address uint16) uint8 {
+	if len(c.ram) == 0 {
+		return openBus
+	}
+	return c.ram[ramIndex(c.ram, 0, address-0xA000)]
+}
+
+func (c *romOnly) WriteRAM(address uint16, value uint8) {
+	if len(c.ram) == 0 {
+		return
+	}
+	c.ram[ramIndex(c.ram, 0, address-0xA000)] = value
+}
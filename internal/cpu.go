@@ -1,7 +1,8 @@
 package internal
 
 import (
-	"log"
+	"context"
+	"fmt"
 	"log/slog"
 	"unsafe"
 )
@@ -191,295 +192,133 @@ func (r *registers) l() uint8 {
 type cpu struct {
 	registers registers
 	ime       bool // ime (interrupt master enable) flag indicating if interrupts are enabled (1) or disabled (0)
+	imeDelay  bool // imeDelay is set by EI, IME is set after the instruction following EI
+	halted    bool // halted is set by HALT and indicates the cpu waits for an interrupt
+	haltBug   bool // haltBug is set by HALT with IME=0 and a pending interrupt, PC is not incremented on the next fetch
+	stopped   bool // stopped is set by STOP and indicates the cpu is in very low power mode
+
+	symbols *Symbols // symbols name the traced instructions, may be nil
+}
+
+//go:generate go run ../cmd/generateOpcodeTable -o opcodeTable.go
+
+// flagEffects describes how an instruction affects the flags, as listed in Opcodes.json. All bit masks use the layout
+// of the F register (Z N H C in bits 7-4).
+type flagEffects struct {
+	keep  uint8 // flags that are not affected ("-")
+	reset uint8 // flags that are always reset ("0")
+	set   uint8 // flags that are always set ("1")
+}
+
+// instruction is an entry of the generated opcode tables.
+type instruction struct {
+	name   string // mnemonic with operands, e.g. "LD B, n8"
+	bytes  int    // length of the instruction including the opcode (and prefix)
+	cycles [2]int // T-cycles when a branch is taken, and when it is not taken (identical for other instructions)
+	flags  flagEffects
+	// exec runs the handler and reports whether a conditional branch was taken (always true for other instructions)
+	exec func(cpu *cpu, bus *Bus) bool
+}
+
+// execute runs the instruction handler and applies the flag effects of the opcode table to the F register. It returns
+// the number of T-cycles the instruction took.
+func (cpu *cpu) execute(bus *Bus, instruction *instruction) int {
+	flags := cpu.registers.flags()
+	before := *flags.data
+
+	taken := instruction.exec(cpu, bus)
+
+	computed := ^(instruction.flags.keep | instruction.flags.reset | instruction.flags.set)
+	*flags.data = before&instruction.flags.keep | *flags.data&computed | instruction.flags.set
+
+	if !taken {
+		return instruction.cycles[1]
+	}
+	return instruction.cycles[0]
+}
+
+// symbol returns the name of the program counter. Addresses in 0x4000-0x7FFF are looked up in the bank mapped by the
+// cartridge, they have no name if the cartridge doesn't report it.
+func (cpu *cpu) symbol(bus *Bus, programCounter uint16) (string, bool) {
+	location := BankAddress{0, programCounter}
+	if programCounter >= 0x4000 && programCounter < 0x8000 {
+		cartridge, ok := bus.cartridge.(bankedCartridge)
+		if !ok {
+			return "", false
+		}
+		location.Bank = uint16(cartridge.mappedROMBank())
+	}
+	return cpu.symbols.Lookup(location)
+}
+
+// logInstruction traces the instruction at the debug level. The bytes are read past the access hooks, so tracing
+// doesn't count as a memory access.
+func (cpu *cpu) logInstruction(bus *Bus, programCounter uint16, instruction *instruction) {
+	if !slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+		return
+	}
+	data := make([]byte, instruction.bytes)
+	for i := range data {
+		address := programCounter + uint16(i)
+		data[i] = bus.readRegion(RegionOf(address), address)
+	}
+	attributes := []any{"PC", fmtHex16(programCounter), "mem", fmt.Sprintf("0x% 2X", data), "instruction", instruction.name}
+	if name, ok := cpu.symbol(bus, programCounter); ok {
+		attributes = append(attributes, "symbol", name)
+	}
+	slog.Debug("Instruction", attributes...)
+}
+
+// runInstruction fetches, decodes and executes the instruction at PC and returns the number of T-cycles it took. For
+// 0xCB-prefixed instructions the returned cycles include the prefix.
+func (cpu *cpu) runInstruction(bus *Bus) int {
+	pc := cpu.registers.pc
+	opcode := readUnsigned8(bus, &cpu.registers.pc)
+	if cpu.haltBug {
+		cpu.haltBug = false
+		cpu.registers.pc--
+	}
+
+	instruction := &unprefixedInstructions[opcode]
+	if isPrefixed(opcode) {
+		opcode = readUnsigned8(bus, &cpu.registers.pc)
+		instruction = &prefixedInstructions[opcode]
+	}
+
+	cpu.logInstruction(bus, pc, instruction)
+	return cpu.execute(bus, instruction)
 }
 
-func (cpu *cpu) runInstruction(memory *memory) {
-	opcode := memory.read(cpu.registers.pc)
-	slog.Debug("Decode instruction", "PC", fmtHex16(cpu.registers.pc), "Opcode", fmtHex8(opcode))
-	cpu.registers.pc++
-
-	// instead of a switch we could also read from an array/slice at the opcode position
-	switch opcode {
-	case 0x00:
-		nop(cpu.registers.pc)
-
-	case 0x01:
-		load16BitToRegister(memory, &cpu.registers.pc, &cpu.registers.bc, "BC")
-	case 0x11:
-		load16BitToRegister(memory, &cpu.registers.pc, &cpu.registers.de, "DE")
-	case 0x21:
-		load16BitToRegister(memory, &cpu.registers.pc, &cpu.registers.hl, "HL")
-	case 0x31:
-		load16BitToRegister(memory, &cpu.registers.pc, &cpu.registers.sp, "SP")
-
-	case 0x3E:
-		load8BitToRegister(memory, &cpu.registers.pc, cpu.registers.aPtr(), "A")
-	case 0x06:
-		load8BitToRegister(memory, &cpu.registers.pc, cpu.registers.bPtr(), "B")
-	case 0x0E:
-		load8BitToRegister(memory, &cpu.registers.pc, cpu.registers.cPtr(), "C")
-	case 0x16:
-		load8BitToRegister(memory, &cpu.registers.pc, cpu.registers.dPtr(), "D")
-	case 0x1E:
-		load8BitToRegister(memory, &cpu.registers.pc, cpu.registers.ePtr(), "E")
-	case 0x26:
-		load8BitToRegister(memory, &cpu.registers.pc, cpu.registers.hPtr(), "H")
-	case 0x2E:
-		load8BitToRegister(memory, &cpu.registers.pc, cpu.registers.lPtr(), "L")
-
-	case 0x36:
-		load8BitToAddressInHL(memory, &cpu.registers.pc, cpu.registers.hl)
-
-	case 0x40:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.bPtr(), cpu.registers.b(), "B", "B")
-	case 0x41:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.bPtr(), cpu.registers.c(), "B", "C")
-	case 0x42:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.bPtr(), cpu.registers.d(), "B", "D")
-	case 0x43:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.bPtr(), cpu.registers.e(), "B", "E")
-	case 0x44:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.bPtr(), cpu.registers.h(), "B", "H")
-	case 0x45:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.bPtr(), cpu.registers.b(), "B", "L")
-	case 0x46:
-		load8BitToRegisterFromAddressInRegister(memory, cpu.registers.pc, cpu.registers.bPtr(), cpu.registers.hl, "B", "HL")
-
-	case 0x48:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.cPtr(), cpu.registers.b(), "C", "B")
-	case 0x49:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.cPtr(), cpu.registers.c(), "C", "C")
-	case 0x4A:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.cPtr(), cpu.registers.d(), "C", "D")
-	case 0x4B:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.cPtr(), cpu.registers.e(), "C", "E")
-	case 0x4C:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.cPtr(), cpu.registers.h(), "C", "H")
-	case 0x4D:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.cPtr(), cpu.registers.l(), "C", "L")
-	case 0x4E:
-		load8BitToRegisterFromAddressInRegister(memory, cpu.registers.pc, cpu.registers.cPtr(), cpu.registers.hl, "C", "HL")
-
-	case 0x50:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.dPtr(), cpu.registers.b(), "D", "B")
-	case 0x51:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.dPtr(), cpu.registers.c(), "D", "C")
-	case 0x52:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.dPtr(), cpu.registers.d(), "D", "D")
-	case 0x53:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.dPtr(), cpu.registers.e(), "D", "E")
-	case 0x54:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.dPtr(), cpu.registers.h(), "D", "H")
-	case 0x55:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.dPtr(), cpu.registers.l(), "D", "L")
-	case 0x56:
-		load8BitToRegisterFromAddressInRegister(memory, cpu.registers.pc, cpu.registers.dPtr(), cpu.registers.hl, "D", "HL")
-
-	case 0x58:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.ePtr(), cpu.registers.b(), "E", "B")
-	case 0x59:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.ePtr(), cpu.registers.c(), "E", "C")
-	case 0x5A:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.ePtr(), cpu.registers.d(), "E", "D")
-	case 0x5B:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.ePtr(), cpu.registers.e(), "E", "E")
-	case 0x5C:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.ePtr(), cpu.registers.h(), "E", "H")
-	case 0x5D:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.ePtr(), cpu.registers.l(), "E", "L")
-	case 0x5E:
-		load8BitToRegisterFromAddressInRegister(memory, cpu.registers.pc, cpu.registers.ePtr(), cpu.registers.hl, "E", "HL")
-
-	case 0x60:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.hPtr(), cpu.registers.b(), "H", "B")
-	case 0x61:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.hPtr(), cpu.registers.c(), "H", "C")
-	case 0x62:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.hPtr(), cpu.registers.d(), "H", "D")
-	case 0x63:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.hPtr(), cpu.registers.e(), "H", "E")
-	case 0x64:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.hPtr(), cpu.registers.h(), "H", "H")
-	case 0x65:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.hPtr(), cpu.registers.l(), "H", "L")
-	case 0x66:
-		load8BitToRegisterFromAddressInRegister(memory, cpu.registers.pc, cpu.registers.hPtr(), cpu.registers.hl, "H", "HL")
-
-	case 0x68:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.lPtr(), cpu.registers.b(), "L", "B")
-	case 0x69:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.lPtr(), cpu.registers.c(), "L", "C")
-	case 0x6A:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.lPtr(), cpu.registers.d(), "L", "D")
-	case 0x6B:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.lPtr(), cpu.registers.e(), "L", "E")
-	case 0x6C:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.lPtr(), cpu.registers.h(), "L", "H")
-	case 0x6D:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.lPtr(), cpu.registers.l(), "L", "L")
-	case 0x6E:
-		load8BitToRegisterFromAddressInRegister(memory, cpu.registers.pc, cpu.registers.lPtr(), cpu.registers.hl, "L", "HL")
-
-	case 0x70:
-		load8BitToAddressInHLFromRegister(memory, cpu.registers.pc, cpu.registers.bPtr(), cpu.registers.hl, "B")
-	case 0x71:
-		load8BitToAddressInHLFromRegister(memory, cpu.registers.pc, cpu.registers.cPtr(), cpu.registers.hl, "C")
-	case 0x72:
-		load8BitToAddressInHLFromRegister(memory, cpu.registers.pc, cpu.registers.dPtr(), cpu.registers.hl, "D")
-	case 0x73:
-		load8BitToAddressInHLFromRegister(memory, cpu.registers.pc, cpu.registers.ePtr(), cpu.registers.hl, "E")
-	case 0x74:
-		load8BitToAddressInHLFromRegister(memory, cpu.registers.pc, cpu.registers.hPtr(), cpu.registers.hl, "H")
-	case 0x75:
-		load8BitToAddressInHLFromRegister(memory, cpu.registers.pc, cpu.registers.lPtr(), cpu.registers.hl, "L")
-
-	case 0x7F:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.a(), "A", "A")
-	case 0x78:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.b(), "A", "B")
-	case 0x79:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.c(), "A", "C")
-	case 0x7A:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.d(), "A", "D")
-	case 0x7B:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.e(), "A", "E")
-	case 0x7C:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.h(), "A", "H")
-	case 0x7D:
-		load8BitToRegisterFromRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.l(), "A", "L")
-
-	case 0x0A:
-		load8BitToRegisterFromAddressInRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.bc, "A", "BC")
-	case 0x1A:
-		load8BitToRegisterFromAddressInRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.de, "A", "DE")
-	case 0x7E:
-		load8BitToRegisterFromAddressInRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.hl, "A", "HL")
-
-	case 0xC3:
-		jp(memory, &cpu.registers.pc)
-
-	case 0x18:
-		relativeJump(memory, &cpu.registers.pc)
-
-	case 0x20:
-		relativeJumpConditional(memory, &cpu.registers.pc, !cpu.registers.flags().z(), "NZ")
-	case 0x28:
-		relativeJumpConditional(memory, &cpu.registers.pc, cpu.registers.flags().z(), "Z")
-	case 0x30:
-		relativeJumpConditional(memory, &cpu.registers.pc, !cpu.registers.flags().c(), "NC")
-	case 0x38:
-		relativeJumpConditional(memory, &cpu.registers.pc, cpu.registers.flags().c(), "c")
-
-	case 0x02:
-		loadFromRegisterIndirect(memory, cpu.registers.pc, cpu.registers.bc, cpu.registers.a(), "BC", "A")
-	case 0x12:
-		loadFromRegisterIndirect(memory, cpu.registers.pc, cpu.registers.de, cpu.registers.a(), "DE", "A")
-	case 0x77:
-		loadFromRegisterIndirect(memory, cpu.registers.pc, cpu.registers.hl, cpu.registers.a(), "HL", "A")
-	case 0xEA:
-		loadFromAccumulatorDirect(memory, &cpu.registers.pc, cpu.registers.a())
-
-	case 0x2A:
-		loadAccumulatorIndirectHLIncrement(memory, cpu.registers.pc, cpu.registers.aPtr(), &cpu.registers.hl)
-
-	case 0x3A:
-		loadAccumulatorIndirectHLDecrement(memory, cpu.registers.pc, cpu.registers.aPtr(), &cpu.registers.hl)
-
-	case 0xF0:
-		loadAccumulatorDirectLeastSignificantByte(memory, &cpu.registers.pc, cpu.registers.aPtr())
-
-	case 0xB7:
-		bitwiseOrRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.flags(), cpu.registers.a(), "A")
-	case 0xB0:
-		bitwiseOrRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.flags(), cpu.registers.b(), "B")
-	case 0xB1:
-		bitwiseOrRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.flags(), cpu.registers.c(), "C")
-	case 0xB2:
-		bitwiseOrRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.flags(), cpu.registers.d(), "D")
-	case 0xB3:
-		bitwiseOrRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.flags(), cpu.registers.e(), "E")
-	case 0xB4:
-		bitwiseOrRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.flags(), cpu.registers.h(), "H")
-	case 0xB5:
-		bitwiseOrRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.flags(), cpu.registers.l(), "L")
-
-	case 0xC9:
-		returnFromFunction(memory, &cpu.registers.pc, &cpu.registers.sp)
-	case 0xC0:
-		returnFromFunctionConditional(memory, &cpu.registers.pc, &cpu.registers.sp, !cpu.registers.flags().z(), "NZ")
-	case 0xC8:
-		returnFromFunctionConditional(memory, &cpu.registers.pc, &cpu.registers.sp, cpu.registers.flags().z(), "Z")
-	case 0xD0:
-		returnFromFunctionConditional(memory, &cpu.registers.pc, &cpu.registers.sp, !cpu.registers.flags().c(), "NC")
-	case 0xD8:
-		returnFromFunctionConditional(memory, &cpu.registers.pc, &cpu.registers.sp, cpu.registers.flags().c(), "C")
-
-	case 0x87:
-		addRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.a(), cpu.registers.flags(), "A")
-	case 0x80:
-		addRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.b(), cpu.registers.flags(), "B")
-	case 0x81:
-		addRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.c(), cpu.registers.flags(), "C")
-	case 0x82:
-		addRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.d(), cpu.registers.flags(), "D")
-	case 0x83:
-		addRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.e(), cpu.registers.flags(), "E")
-	case 0x84:
-		addRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.h(), cpu.registers.flags(), "H")
-	case 0x85:
-		addRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.l(), cpu.registers.flags(), "L")
-	case 0x86:
-		addIndirectHL(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.hl, cpu.registers.flags())
-	case 0xC6:
-		addImmediate(memory, &cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.flags())
-
-	case 0x97:
-		subtractRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.a(), cpu.registers.flags(), "A")
-	case 0x90:
-		subtractRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.b(), cpu.registers.flags(), "B")
-	case 0x91:
-		subtractRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.c(), cpu.registers.flags(), "C")
-	case 0x92:
-		subtractRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.d(), cpu.registers.flags(), "D")
-	case 0x93:
-		subtractRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.e(), cpu.registers.flags(), "E")
-	case 0x94:
-		subtractRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.h(), cpu.registers.flags(), "H")
-	case 0x95:
-		subtractRegister(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.l(), cpu.registers.flags(), "L")
-	case 0x96:
-		subtractIndirectHL(memory, cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.hl, cpu.registers.flags())
-	case 0xD6:
-		subtractImmediate(memory, &cpu.registers.pc, cpu.registers.aPtr(), cpu.registers.flags())
-
-	case 0x03:
-		increment16BitRegister(memory, cpu.registers.pc, &cpu.registers.bc, "BC")
-	case 0x13:
-		increment16BitRegister(memory, cpu.registers.pc, &cpu.registers.de, "DE")
-	case 0x23:
-		increment16BitRegister(memory, cpu.registers.pc, &cpu.registers.hl, "HL")
-	case 0x33:
-		increment16BitRegister(memory, cpu.registers.pc, &cpu.registers.sp, "SP")
-
-	case 0x0B:
-		decrement16BitRegister(memory, cpu.registers.pc, &cpu.registers.bc, "BC")
-	case 0x1B:
-		decrement16BitRegister(memory, cpu.registers.pc, &cpu.registers.de, "DE")
-	case 0x2B:
-		decrement16BitRegister(memory, cpu.registers.pc, &cpu.registers.hl, "HL")
-	case 0x3B:
-		decrement16BitRegister(memory, cpu.registers.pc, &cpu.registers.sp, "SP")
-
-	case 0xFE:
-		compareImmediate(memory, &cpu.registers.pc, cpu.registers.a(), cpu.registers.flags())
-
-	case 0xCD:
-		call(memory, &cpu.registers.pc, &cpu.registers.sp)
-
-	case 0xF3:
-		disableInterrupts(memory, cpu.registers.pc, &cpu.ime)
-
-	default:
-		log.Panicf("unknown opcode: 0x%02X", opcode)
+// lowPowerCycles is the number of T-cycles a step takes while the cpu is halted or stopped.
+const lowPowerCycles = 4
+
+// step services a pending interrupt or runs the next instruction and returns the number of T-cycles it took. The
+// interrupt check happens before the delayed IME of EI takes effect, so the instruction following EI always runs.
+// While halted or stopped, the cpu idles until it is woken up by a pending interrupt or a joypad press.
+func (cpu *cpu) step(bus *Bus) int {
+	if cpu.stopped {
+		if bus.interrupts == nil || bus.interrupts.flag&uint8(joypadInterrupt) == 0 {
+			return lowPowerCycles
+		}
+		cpu.stopped = false
+	}
+
+	if cpu.halted {
+		if bus.interrupts == nil || bus.interrupts.pending() == 0 {
+			return lowPowerCycles
+		}
+		// a pending interrupt ends HALT, even if IME is reset (the handler is not called then)
+		cpu.halted = false
+	}
+
+	if cycles := cpu.serviceInterrupt(bus); cycles != 0 {
+		return cycles
+	}
+
+	if cpu.imeDelay {
+		cpu.imeDelay = false
+		cpu.ime = true
 	}
+
+	return cpu.runInstruction(bus)
 }
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -67,3 +68,239 @@ func TestSetAndClearBits(t *testing.T) {
 		assert.Equal(t, test.result, value)
 	}
 }
+
+func newTestCpu(program []byte) (*cpu, *Bus) {
+	gb := newTestGameBoy(program)
+	return &gb.cpu, &gb.bus
+}
+
+func newTestGameBoy(program []byte) *GameBoy {
+	gb := NewGameBoy()
+	rom := make([]byte, 2*romBankSize)
+	copy(rom[0x0100:], program)
+	gb.bus.cartridge = &romOnly{rom: rom}
+	gb.cpu.registers.pc = 0x0100
+	gb.cpu.registers.sp = 0xFFFE
+	return gb
+}
+
+// pokeROM changes the ROM of the test cartridge.
+func pokeROM(bus *Bus, address uint16, value uint8) {
+	bus.cartridge.(*romOnly).rom[address] = value
+}
+
+func runInstructions(c *cpu, m *Bus, n int) {
+	for i := 0; i < n; i++ {
+		c.runInstruction(m)
+	}
+}
+
+func TestArithmeticFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []byte
+		steps   int
+		a       uint8
+		flags   uint8
+	}{
+		{"ADD half carry", []byte{0x3E, 0x0F, 0x06, 0x01, 0x80}, 3, 0x10, 0b0010_0000},
+		{"ADD carry and zero", []byte{0x3E, 0xFF, 0x06, 0x01, 0x80}, 3, 0x00, 0b1011_0000},
+		{"ADC with carry", []byte{0x37, 0x3E, 0x0E, 0xCE, 0x01}, 3, 0x10, 0b0010_0000},
+		{"SUB A, A", []byte{0x3E, 0x42, 0x97}, 2, 0x00, 0b1100_0000},
+		{"SBC borrow", []byte{0x37, 0x3E, 0x00, 0xDE, 0x00}, 3, 0xFF, 0b0111_0000},
+		{"AND", []byte{0x3E, 0xF0, 0xE6, 0x0F}, 2, 0x00, 0b1010_0000},
+		{"XOR A, A", []byte{0x3E, 0x42, 0xAF}, 2, 0x00, 0b1000_0000},
+		{"CP keeps A", []byte{0x3E, 0x10, 0x06, 0x20, 0xB8}, 3, 0x10, 0b0101_0000},
+		{"INC keeps carry", []byte{0x37, 0x3E, 0xFF, 0x3C}, 3, 0x00, 0b1011_0000},
+		{"DEC half borrow", []byte{0x3E, 0x10, 0x3D}, 2, 0x0F, 0b0110_0000},
+		{"DAA after ADD", []byte{0x3E, 0x19, 0xC6, 0x28, 0x27}, 3, 0x47, 0b0000_0000},
+		{"DAA after SUB", []byte{0x3E, 0x47, 0xD6, 0x28, 0x27}, 3, 0x19, 0b0100_0000},
+		{"CPL", []byte{0x3E, 0x0F, 0x2F}, 2, 0xF0, 0b0110_0000},
+		{"RLCA", []byte{0x3E, 0x85, 0x07}, 2, 0x0B, 0b0001_0000},
+		{"RRA through carry", []byte{0x37, 0x3E, 0x02, 0x1F}, 3, 0x81, 0b0000_0000},
+		{"CCF", []byte{0x37, 0x3F}, 2, 0x00, 0b0000_0000},
+	}
+
+	for _, test := range tests {
+		c, m := newTestCpu(test.program)
+		runInstructions(c, m, test.steps)
+		assert.Equal(t, test.a, c.registers.a(), test.name)
+		assert.Equal(t, test.flags, lowPart(c.registers.af), test.name)
+	}
+}
+
+func TestLoadRegisterFromRegister(t *testing.T) {
+	// LD L, 0x42; LD B, L
+	c, m := newTestCpu([]byte{0x2E, 0x42, 0x45})
+	runInstructions(c, m, 2)
+	assert.Equal(t, uint8(0x42), c.registers.b())
+}
+
+func TestLoadIndirectHLFromRegister(t *testing.T) {
+	// LD HL, 0xC000; LD B, 0x42; LD [HL], B
+	c, m := newTestCpu([]byte{0x21, 0x00, 0xC0, 0x06, 0x42, 0x70})
+	runInstructions(c, m, 3)
+	assert.Equal(t, uint8(0x42), m.read(0xC000))
+	assert.Equal(t, uint8(0x42), c.registers.b())
+}
+
+func TestPushPop(t *testing.T) {
+	// LD BC, 0x12FF; PUSH BC; POP AF; PUSH AF; POP DE
+	c, m := newTestCpu([]byte{0x01, 0xFF, 0x12, 0xC5, 0xF1, 0xF5, 0xD1})
+	runInstructions(c, m, 5)
+	assert.Equal(t, uint16(0x12F0), c.registers.af)
+	assert.Equal(t, uint16(0x12F0), c.registers.de)
+	assert.Equal(t, uint16(0xFFFE), c.registers.sp)
+}
+
+func TestAdd16BitAndStackPointer(t *testing.T) {
+	// LD HL, 0x0FFF; LD BC, 0x0001; ADD HL, BC
+	c, m := newTestCpu([]byte{0x21, 0xFF, 0x0F, 0x01, 0x01, 0x00, 0x09})
+	runInstructions(c, m, 3)
+	assert.Equal(t, uint16(0x1000), c.registers.hl)
+	assert.Equal(t, uint8(0b0010_0000), lowPart(c.registers.af))
+
+	// LD SP, 0xFFF8; LD HL, SP+8; ADD SP, -8
+	c, m = newTestCpu([]byte{0x31, 0xF8, 0xFF, 0xF8, 0x08, 0xE8, 0xF8})
+	runInstructions(c, m, 2)
+	assert.Equal(t, uint16(0x0000), c.registers.hl)
+	assert.Equal(t, uint8(0b0011_0000), lowPart(c.registers.af))
+	runInstructions(c, m, 1)
+	assert.Equal(t, uint16(0xFFF0), c.registers.sp)
+}
+
+func TestControlFlow(t *testing.T) {
+	// XOR A; CALL NZ, 0x0200; CALL Z, 0x0200
+	c, m := newTestCpu([]byte{0xAF, 0xC4, 0x00, 0x02, 0xCC, 0x00, 0x02})
+	// 0x0200: RST 0x08, 0x0008: RETI
+	pokeROM(m, 0x0200, 0xCF)
+	pokeROM(m, 0x0008, 0xD9)
+	runInstructions(c, m, 2)
+	assert.Equal(t, uint16(0x0104), c.registers.pc)
+	runInstructions(c, m, 1)
+	assert.Equal(t, uint16(0x0200), c.registers.pc)
+	runInstructions(c, m, 1)
+	assert.Equal(t, uint16(0x0008), c.registers.pc)
+	runInstructions(c, m, 1)
+	assert.Equal(t, uint16(0x0201), c.registers.pc)
+	assert.True(t, c.ime)
+
+	// LD HL, 0x1234; JP HL
+	c, m = newTestCpu([]byte{0x21, 0x34, 0x12, 0xE9})
+	runInstructions(c, m, 2)
+	assert.Equal(t, uint16(0x1234), c.registers.pc)
+}
+
+func TestHighMemoryLoads(t *testing.T) {
+	// LD A, 0x42; LDH [0x80], A; LD C, 0x80; LD A, 0x00; LD A, [C]; LD [0xC000], SP
+	c, m := newTestCpu([]byte{0x3E, 0x42, 0xE0, 0x80, 0x0E, 0x80, 0x3E, 0x00, 0xF2, 0x08, 0x00, 0xC0})
+	runInstructions(c, m, 6)
+	assert.Equal(t, uint8(0x42), m.read(0xFF80))
+	assert.Equal(t, uint8(0x42), c.registers.a())
+	assert.Equal(t, uint8(0xFE), m.read(0xC000))
+	assert.Equal(t, uint8(0xFF), m.read(0xC001))
+}
+
+func TestPrefixedInstructions(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []byte
+		steps   int
+		b       uint8
+		flags   uint8
+	}{
+		{"RLC", []byte{0x06, 0x80, 0xCB, 0x00}, 2, 0x01, 0b0001_0000},
+		{"RRC zero", []byte{0x06, 0x00, 0xCB, 0x08}, 2, 0x00, 0b1000_0000},
+		{"RL through carry", []byte{0x37, 0x06, 0x40, 0xCB, 0x10}, 3, 0x81, 0b0000_0000},
+		{"RR through carry", []byte{0x06, 0x01, 0xCB, 0x18}, 2, 0x00, 0b1001_0000},
+		{"SLA", []byte{0x06, 0xC0, 0xCB, 0x20}, 2, 0x80, 0b0001_0000},
+		{"SRA keeps sign", []byte{0x06, 0x81, 0xCB, 0x28}, 2, 0xC0, 0b0001_0000},
+		{"SWAP", []byte{0x06, 0x12, 0xCB, 0x30}, 2, 0x21, 0b0000_0000},
+		{"SRL", []byte{0x06, 0x81, 0xCB, 0x38}, 2, 0x40, 0b0001_0000},
+		{"BIT not set keeps carry", []byte{0x37, 0x06, 0x7F, 0xCB, 0x78}, 3, 0x7F, 0b1011_0000},
+		{"BIT set", []byte{0x06, 0x01, 0xCB, 0x40}, 2, 0x01, 0b0010_0000},
+		{"RES", []byte{0x06, 0xFF, 0xCB, 0x98}, 2, 0xF7, 0b0000_0000},
+		{"SET", []byte{0x06, 0x00, 0xCB, 0xF8}, 2, 0x80, 0b0000_0000},
+	}
+
+	for _, test := range tests {
+		c, m := newTestCpu(test.program)
+		runInstructions(c, m, test.steps)
+		assert.Equal(t, test.b, c.registers.b(), test.name)
+		assert.Equal(t, test.flags, lowPart(c.registers.af), test.name)
+	}
+}
+
+func TestPrefixedInstructionsIndirectHL(t *testing.T) {
+	// LD HL, 0xC000; SET 3, [HL]; SWAP [HL]; BIT 7, [HL]; RES 7, [HL]
+	c, m := newTestCpu([]byte{0x21, 0x00, 0xC0, 0xCB, 0xDE, 0xCB, 0x36, 0xCB, 0x7E, 0xCB, 0xBE})
+	runInstructions(c, m, 3)
+	assert.Equal(t, uint8(0x80), m.read(0xC000))
+	runInstructions(c, m, 1)
+	assert.Equal(t, uint8(0b0010_0000), lowPart(c.registers.af))
+	runInstructions(c, m, 1)
+	assert.Equal(t, uint8(0x00), m.read(0xC000))
+	assert.Equal(t, uint16(0x010B), c.registers.pc)
+}
+
+func TestOpcodeTableMatchesOpcodes(t *testing.T) {
+	opcodes, err := ParseOpcodes()
+	assert.NoError(t, err)
+
+	tables := []struct {
+		instructions *[256]instruction
+		opcodes      map[ByteKey]Opcode
+	}{
+		{&unprefixedInstructions, opcodes.UnPrefixed},
+		{&prefixedInstructions, opcodes.CbPrefixed},
+	}
+
+	for _, table := range tables {
+		for i, instruction := range table.instructions {
+			opcode := table.opcodes[ByteKey{Value: byte(i)}]
+			assert.True(t, strings.HasPrefix(instruction.name, opcode.Mnemonic), "0x%02X: run go generate", i)
+			assert.Equal(t, opcode.Bytes, instruction.bytes, "0x%02X: run go generate", i)
+			assert.Equal(t, opcode.Cycles[0], instruction.cycles[0], "0x%02X: run go generate", i)
+		}
+	}
+}
+
+func TestInstructionCycles(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []byte
+		steps   int
+		cycles  int
+	}{
+		{"NOP", []byte{0x00}, 1, 4},
+		{"LD BC, n16", []byte{0x01, 0x34, 0x12}, 1, 12},
+		{"JR NZ taken", []byte{0x20, 0x00}, 1, 12},
+		{"JR Z not taken", []byte{0x28, 0x00}, 1, 8},
+		{"CALL NZ taken", []byte{0xC4, 0x00, 0x02}, 1, 24},
+		{"RET Z not taken", []byte{0xC8}, 1, 8},
+		{"BIT 0, [HL]", []byte{0xCB, 0x46}, 1, 12},
+		{"SWAP [HL]", []byte{0xCB, 0x36}, 1, 16},
+	}
+
+	for _, test := range tests {
+		c, m := newTestCpu(test.program)
+		cycles := 0
+		for i := 0; i < test.steps; i++ {
+			cycles += c.runInstruction(m)
+		}
+		assert.Equal(t, test.cycles, cycles, test.name)
+	}
+}
+
+func TestMasterClock(t *testing.T) {
+	// NOP; LD A, n8; INC A
+	gb := newTestGameBoy([]byte{0x00, 0x3E, 0x41, 0x3C})
+
+	for i := 0; i < 3; i++ {
+		gb.step()
+	}
+	assert.Equal(t, uint64(16), gb.Cycles())
+	assert.Equal(t, uint16(16), gb.timer.counter)
+	assert.Equal(t, 16, gb.ppu.dot)
+	assert.Equal(t, 16, gb.apu.cycles)
+}
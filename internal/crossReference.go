@@ -0,0 +1,273 @@
+package internal
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// compareLocations orders locations by bank and address.
+func compareLocations(a, b BankAddress) int {
+	if a.Bank != b.Bank {
+		return cmp.Compare(a.Bank, b.Bank)
+	}
+	return cmp.Compare(a.Address, b.Address)
+}
+
+// sortedLocations returns the keys of the set ordered by bank and address.
+func sortedLocations[V any](set map[BankAddress]V) []BankAddress {
+	locations := make([]BankAddress, 0, len(set))
+	for location := range set {
+		locations = append(locations, location)
+	}
+	slices.SortFunc(locations, compareLocations)
+	return locations
+}
+
+// BasicBlock is a sequence of instructions only entered at the first and only left after the last instruction.
+type BasicBlock struct {
+	Start        BankAddress
+	Instructions []Instruction
+	// Successors are the blocks control may continue with, calls are not included
+	Successors []BankAddress
+}
+
+// Routine is the code reachable from a call target or an entry point without following calls.
+type Routine struct {
+	Entry BankAddress
+	// Blocks are ordered by location
+	Blocks []*BasicBlock
+	// Callers are the routines calling this routine
+	Callers []BankAddress
+	// Callees are the routines called by this routine
+	Callees []BankAddress
+}
+
+// MemoryAccess is an instruction reading or writing a RAM or I/O address. Instructions like INC [HL] that read and
+// write the address are listed twice.
+type MemoryAccess struct {
+	Site    BankAddress
+	Address uint16
+	Write   bool
+}
+
+// CrossReference relates the routines of a disassembled rom to each other and to the RAM and I/O addresses they
+// access.
+type CrossReference struct {
+	Routines map[BankAddress]*Routine
+	// Accesses lists the sites accessing each RAM and I/O address (0x8000-0xFFFF), ordered by location
+	Accesses map[uint16][]MemoryAccess
+}
+
+// nextLocation returns the location of the instruction following i.
+func nextLocation(i *Instruction) BankAddress {
+	if i.Bank == 0 && i.AddressEnd == 0x4000 {
+		// code running off the end of bank 0 continues in the mapped bank
+		return BankAddress{i.TargetBank, 0x4000}
+	}
+	return BankAddress{i.Bank, i.AddressEnd}
+}
+
+// readModifyWrite are the operations that read and write back their memory operand.
+var readModifyWrite = map[string]bool{
+	"INC": true, "DEC": true, "RLC": true, "RRC": true, "RL": true, "RR": true,
+	"SLA": true, "SRA": true, "SWAP": true, "SRL": true, "RES": true, "SET": true,
+}
+
+// memoryAccesses returns the RAM and I/O accesses of the instruction, at addresses given by the instruction or held by
+// register pairs with known values, and 0xFF00+C for LDH [C].
+func memoryAccesses(i *Instruction, state *bankState) []MemoryAccess {
+	var accesses []MemoryAccess
+	for index, operand := range i.Operands {
+		address := state.address(operand)
+		if address == unknownValue || address < 0x8000 {
+			continue
+		}
+		site := i.Location()
+		switch {
+		case readModifyWrite[i.Opcode.Mnemonic]:
+			accesses = append(accesses, MemoryAccess{site, uint16(address), false}, MemoryAccess{site, uint16(address), true})
+		default:
+			// the destination of loads is the first operand, all other operations only read memory
+			write := index == 0 && (i.Opcode.Mnemonic == "LD" || i.Opcode.Mnemonic == "LDH")
+			accesses = append(accesses, MemoryAccess{site, uint16(address), write})
+		}
+	}
+	return accesses
+}
+
+// NewCrossReference finds the routines starting at the entry points and at all call targets of the instructions, as
+// returned by DisassembleRecursive. Accesses through [HL], [BC], [DE] and [C] are only resolved if the register is
+// loaded with a constant earlier in the same basic block, values set by callers or other blocks are not known.
+func NewCrossReference(instructions []Instruction, entryPoints []BankAddress) *CrossReference {
+	x := &CrossReference{Routines: make(map[BankAddress]*Routine), Accesses: make(map[uint16][]MemoryAccess)}
+
+	code := make(map[BankAddress]*Instruction)
+	// leaders start basic blocks
+	leaders := make(map[BankAddress]bool)
+	entries := make(map[BankAddress]bool)
+	for _, entry := range entryPoints {
+		leaders[entry] = true
+		entries[entry] = true
+	}
+	for k := range instructions {
+		i := &instructions[k]
+		if i.Data {
+			continue
+		}
+		code[i.Location()] = i
+		if target, ok := i.TargetLocation(); ok {
+			leaders[target] = true
+			if i.Flow == FlowCall {
+				entries[target] = true
+			}
+		}
+	}
+
+	// follow the register values through each basic block
+	var state bankState
+	var previous *Instruction
+	for k := range instructions {
+		i := &instructions[k]
+		if i.Data {
+			previous = nil
+			continue
+		}
+		if previous == nil || !previous.FallsThrough() || previous.Flow != FlowNone || leaders[i.Location()] ||
+			nextLocation(previous) != i.Location() {
+			state = newBankState(unknownValue)
+		}
+		for _, access := range memoryAccesses(i, &state) {
+			x.Accesses[access.Address] = append(x.Accesses[access.Address], access)
+		}
+		state.update(i, bankSwitching{controller: controllerNone})
+		previous = i
+	}
+
+	for entry := range entries {
+		if _, ok := code[entry]; ok {
+			x.Routines[entry] = newRoutine(entry, code, leaders)
+		}
+	}
+
+	// relate callers and callees
+	callers := make(map[BankAddress]map[BankAddress]bool)
+	for _, routine := range x.Routines {
+		callees := make(map[BankAddress]bool)
+		for _, block := range routine.Blocks {
+			for _, i := range block.Instructions {
+				if target, ok := i.TargetLocation(); ok && i.Flow == FlowCall && x.Routines[target] != nil {
+					callees[target] = true
+					if callers[target] == nil {
+						callers[target] = make(map[BankAddress]bool)
+					}
+					callers[target][routine.Entry] = true
+				}
+			}
+		}
+		routine.Callees = sortedLocations(callees)
+	}
+	for entry, routine := range x.Routines {
+		routine.Callers = sortedLocations(callers[entry])
+	}
+	return x
+}
+
+// newRoutine collects the basic blocks reachable from the entry.
+func newRoutine(entry BankAddress, code map[BankAddress]*Instruction, leaders map[BankAddress]bool) *Routine {
+	blocks := make(map[BankAddress]*BasicBlock)
+	work := []BankAddress{entry}
+	for len(work) > 0 {
+		start := work[len(work)-1]
+		work = work[:len(work)-1]
+		if blocks[start] != nil || code[start] == nil {
+			continue
+		}
+
+		block := &BasicBlock{Start: start}
+		blocks[start] = block
+		for location := start; ; {
+			i := code[location]
+			block.Instructions = append(block.Instructions, *i)
+
+			next := nextLocation(i)
+			if target, ok := i.TargetLocation(); ok && i.Flow == FlowJump && code[target] != nil {
+				block.Successors = append(block.Successors, target)
+			}
+			if i.FallsThrough() && code[next] != nil {
+				if i.Flow == FlowJump || i.Flow == FlowReturn || leaders[next] {
+					block.Successors = append(block.Successors, next)
+				} else {
+					location = next
+					continue
+				}
+			}
+			break
+		}
+		work = append(work, block.Successors...)
+	}
+
+	routine := &Routine{Entry: entry}
+	for _, start := range sortedLocations(blocks) {
+		routine.Blocks = append(routine.Blocks, blocks[start])
+	}
+	return routine
+}
+
+// SortedRoutines returns the routines ordered by the location of their entry.
+func (x *CrossReference) SortedRoutines() []*Routine {
+	routines := make([]*Routine, 0, len(x.Routines))
+	for _, entry := range sortedLocations(x.Routines) {
+		routines = append(routines, x.Routines[entry])
+	}
+	return routines
+}
+
+// dotName returns the quoted name of the location in a DOT graph.
+func dotName(location BankAddress, symbols *Symbols) string {
+	if name, ok := symbols.Lookup(location); ok {
+		return fmt.Sprintf("%q", name)
+	}
+	return fmt.Sprintf("%q", location.String())
+}
+
+// WriteCallGraphDOT writes the routines and their calls as a Graphviz DOT graph.
+func (x *CrossReference) WriteCallGraphDOT(w io.Writer, symbols *Symbols) error {
+	var b strings.Builder
+	b.WriteString("digraph calls {\n\tnode [shape=box];\n")
+	for _, routine := range x.SortedRoutines() {
+		fmt.Fprintf(&b, "\t%s;\n", dotName(routine.Entry, symbols))
+		for _, callee := range routine.Callees {
+			fmt.Fprintf(&b, "\t%s -> %s;\n", dotName(routine.Entry, symbols), dotName(callee, symbols))
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteControlFlowDOT writes the basic blocks of the routine and their successors as a Graphviz DOT graph.
+func (r *Routine) WriteControlFlowDOT(w io.Writer, symbols *Symbols) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %s {\n\tnode [shape=box, fontname=monospace];\n", dotName(r.Entry, symbols))
+	for _, block := range r.Blocks {
+		var lines []string
+		if name, ok := symbols.Lookup(block.Start); ok {
+			lines = append(lines, name+":")
+		}
+		for _, i := range block.Instructions {
+			lines = append(lines, fmt.Sprintf("%s %s", i.Location(), symbols.FormatInstruction(i)))
+		}
+		label := strings.ReplaceAll(strings.Join(lines, "\n"), `"`, `\"`)
+		// \l left-aligns the lines
+		fmt.Fprintf(&b, "\t%q [label=\"%s\\l\"];\n", block.Start.String(), strings.ReplaceAll(label, "\n", `\l`))
+		for _, successor := range block.Successors {
+			fmt.Fprintf(&b, "\t%q -> %q;\n", block.Start.String(), successor.String())
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
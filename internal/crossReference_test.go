@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCrossReference(t *testing.T) {
+	opcodes, err := ParseOpcodes()
+	assert.NoError(t, err)
+
+	rom := make([]byte, 0x0200)
+	copy(rom[0x0100:], []byte{
+		0xCD, 0x60, 0x01, // Main: CALL Wait
+		0xCD, 0x70, 0x01, // CALL Count
+		0x18, 0xF8, // JR Main
+	})
+	copy(rom[0x0160:], []byte{
+		0xF0, 0x44, // Wait: LDH A, [rLY]
+		0xFE, 0x90, // CP 0x90
+		0x20, 0xFA, // JR NZ, Wait
+		0xC9, // RET
+	})
+	copy(rom[0x0170:], []byte{
+		0xFA, 0x00, 0xC0, // Count: LD A, [0xC000]
+		0x3C,             // INC A
+		0xEA, 0x00, 0xC0, // LD [0xC000], A
+		0xCD, 0x60, 0x01, // CALL Wait
+		0xC9, // RET
+	})
+
+	instructions := DisassembleRecursive(rom, []uint16{entryPointAddress}, opcodes)
+	x := NewCrossReference(instructions, []BankAddress{{0, entryPointAddress}})
+
+	main, wait, count := BankAddress{0, 0x0100}, BankAddress{0, 0x0160}, BankAddress{0, 0x0170}
+	assert.Len(t, x.Routines, 3)
+	assert.Equal(t, []BankAddress{wait, count}, x.Routines[main].Callees)
+	assert.Empty(t, x.Routines[main].Callers)
+	assert.Equal(t, []BankAddress{main, count}, x.Routines[wait].Callers)
+	assert.Empty(t, x.Routines[wait].Callees)
+
+	// the loop in Wait splits it into two blocks
+	blocks := x.Routines[wait].Blocks
+	assert.Len(t, blocks, 2)
+	assert.Equal(t, []BankAddress{wait, {0, 0x0166}}, blocks[0].Successors)
+	assert.Len(t, blocks[0].Instructions, 3)
+	assert.Empty(t, blocks[1].Successors)
+
+	assert.Equal(t, []MemoryAccess{{BankAddress{0, 0x0160}, 0xFF44, false}}, x.Accesses[0xFF44])
+	assert.Equal(t, []MemoryAccess{
+		{BankAddress{0, 0x0170}, 0xC000, false},
+		{BankAddress{0, 0x0174}, 0xC000, true},
+	}, x.Accesses[0xC000])
+
+	symbols := NewSymbols()
+	symbols.Add(wait, "Wait")
+	var out bytes.Buffer
+	assert.NoError(t, x.WriteCallGraphDOT(&out, symbols))
+	assert.Contains(t, out.String(), "\t\"EntryPoint\" -> \"Wait\";\n")
+	assert.Contains(t, out.String(), "\t\"EntryPoint\" -> \"00:0170\";\n")
+
+	out.Reset()
+	assert.NoError(t, x.Routines[wait].WriteControlFlowDOT(&out, symbols))
+	assert.Contains(t, out.String(), `"00:0160" [label="Wait:\l00:0160 LDH A, [rLY]\l00:0162 CP A, 0x90\l00:0164 JR NZ, Wait\l"];`)
+	assert.Contains(t, out.String(), "\t\"00:0160\" -> \"00:0166\";\n")
+}
+
+func TestCrossReferenceRegisterAccesses(t *testing.T) {
+	opcodes, err := ParseOpcodes()
+	assert.NoError(t, err)
+
+	rom := make([]byte, 0x0200)
+	copy(rom[0x0100:], []byte{
+		0x21, 0x00, 0xC1, // LD HL, 0xC100
+		0x22,             // LD [HL+], A
+		0x34,             // INC [HL]
+		0x01, 0x00, 0xC2, // LD BC, 0xC200
+		0x0A,       // LD A, [BC]
+		0x0E, 0x80, // LD C, 0x80
+		0xE2,       // LD [C], A
+		0xCB, 0xCE, // SET 1, [HL]
+		0xCD, 0x20, 0x01, // CALL 0x0120
+		0x77,       // LD [HL], A
+		0x18, 0xFE, // JR 0x0112
+	})
+	copy(rom[0x0120:], []byte{
+		0x7E, // LD A, [HL]
+		0xC9, // RET
+	})
+
+	instructions := DisassembleRecursive(rom, []uint16{entryPointAddress}, opcodes)
+	x := NewCrossReference(instructions, []BankAddress{{0, entryPointAddress}})
+
+	site := func(address uint16) BankAddress {
+		return BankAddress{0, address}
+	}
+	assert.Equal(t, []MemoryAccess{{site(0x0103), 0xC100, true}}, x.Accesses[0xC100])
+	assert.Equal(t, []MemoryAccess{
+		{site(0x0104), 0xC101, false},
+		{site(0x0104), 0xC101, true},
+		{site(0x010C), 0xC101, false},
+		{site(0x010C), 0xC101, true},
+	}, x.Accesses[0xC101])
+	assert.Equal(t, []MemoryAccess{{site(0x0108), 0xC200, false}}, x.Accesses[0xC200])
+	assert.Equal(t, []MemoryAccess{{site(0x010B), 0xFF80, true}}, x.Accesses[0xFF80])
+	// HL is not known after the call and in the callee
+	assert.Len(t, x.Accesses, 4)
+}
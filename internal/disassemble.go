@@ -1,190 +1,258 @@
 package internal
 
 import (
-	"fmt"
-	"log"
-	"slices"
+	"encoding/binary"
+	"strings"
 )
 
 func isPrefixed(b byte) bool {
 	return b == 0xCB
 }
 
-func toLittleEndian(b []byte) []byte {
-	address := make([]byte, len(b))
-	copy(address, b)
-	slices.Reverse(address)
-	return address
+// OperandKind classifies the decoded value of an operand.
+type OperandKind int
+
+const (
+	OperandRegister  OperandKind = iota // 8-bit or 16-bit register, Name holds the register
+	OperandCondition                    // condition code Z, NZ, C or NC, Name holds the condition
+	OperandImmediate                    // n8 or n16 data
+	OperandAddress                      // a16 address or a8 offset into 0xFF00-0xFFFF, Value holds the full address
+	OperandRelative                     // e8 jump offset, Value holds the absolute target
+	OperandOffset                       // e8 offset added to SP
+	OperandBit                          // bit index of BIT, RES and SET
+	OperandVector                       // RST target
+)
+
+// OperandValue is an operand decoded from the instruction bytes.
+type OperandValue struct {
+	Kind OperandKind
+	Name string // Name is the operand name of the opcode table, e.g. "HL", "NZ" or "n8"
+	// Value holds the number of immediates, addresses, bits and vectors, and the target of relative jumps
+	Value  uint16
+	Offset int8 // Offset is the signed value of e8 operands
+	// Indirect operands access the memory at the address, i.e. [HL]
+	Indirect  bool
+	Increment bool
+	Decrement bool
 }
 
-func littleEndian16BitAddressOrData(data []byte, programCounter uint16, operand *Operand) (uint16, string) {
-	if operand.Bytes != 2 {
-		log.Fatal("unexpected number of bytes.")
-	}
-	value := toLittleEndian(data[programCounter : programCounter+2])
-	str := fmt.Sprintf("0x%X", value)
-	programCounter += 2
-	return programCounter, str
+// ControlFlow classifies how an instruction transfers control.
+type ControlFlow int
+
+const (
+	FlowNone    ControlFlow = iota // continues with the next instruction
+	FlowJump                       // JP and JR
+	FlowCall                       // CALL and RST
+	FlowReturn                     // RET and RETI
+	FlowStop                       // HALT and STOP
+	FlowIllegal                    // illegal opcodes lock up the cpu
+)
+
+// Instruction is a decoded instruction, or bytes that could not be decoded (Data).
+type Instruction struct {
+	// Bank is the rom bank the instruction is located in, AddressStart and AddressEnd the addresses it is mapped to
+	Bank         uint16
+	AddressStart uint16
+	AddressEnd   uint16
+	// Bytes are the raw bytes of the instruction including the prefix
+	Bytes    []byte
+	Opcode   Opcode
+	Prefixed bool
+	Operands []OperandValue
+	Flow     ControlFlow
+	// Conditional transfers may fall through to the next instruction
+	Conditional bool
+	// TargetBank is the bank mapped to 0x4000-0x7FFF when a jump or call is taken, UnknownBank if it is not known
+	TargetBank uint16
+	// Data marks illegal opcodes and instructions cut off by the end of the data
+	Data bool
 }
 
-func unsigned8BitData(data []byte, programCounter uint16, operand *Operand) (uint16, string) {
-	if operand.Bytes != 1 {
-		log.Fatal("unexpected number of bytes.")
+// Location returns the bank and address of the instruction.
+func (i *Instruction) Location() BankAddress {
+	return BankAddress{i.Bank, i.AddressStart}
+}
+
+// Target returns the address a jump or call transfers to, if it is known without executing the code (JP HL is not).
+func (i *Instruction) Target() (uint16, bool) {
+	if i.Flow != FlowJump && i.Flow != FlowCall {
+		return 0, false
+	}
+	for _, operand := range i.Operands {
+		switch operand.Kind {
+		case OperandAddress, OperandRelative, OperandVector:
+			return operand.Value, true
+		}
 	}
-	str := fmt.Sprintf("0x%.2X", data[programCounter])
-	programCounter++
-	return programCounter, str
+	return 0, false
 }
 
-func signed8BitData(data []byte, programCounter uint16, operand *Operand) (uint16, string) {
-	if operand.Bytes != 1 {
-		log.Fatal("unexpected number of bytes.")
+// TargetLocation returns the bank and address a jump or call transfers to, if it is known.
+func (i *Instruction) TargetLocation() (BankAddress, bool) {
+	target, ok := i.Target()
+	if !ok {
+		return BankAddress{}, false
 	}
-	str := fmt.Sprintf("%d (0x%.2X)", int8(data[programCounter]), data[programCounter])
-	programCounter++
-	return programCounter, str
-}
-
-func handleOperand(data []byte, programCounter uint16, operand *Operand) (uint16, string) {
-	operandStr := ""
-	switch operand.Name {
-	case "AF":
-		// 16-bit register (Accumulator & Flags)
-		operandStr += "AF"
-	case "A":
-		// 8-bit Hi part of AF
-		operandStr += "A"
-	case "BC":
-		// 16-bit register
-		operandStr += "BC"
-	case "B":
-		// 8-bit Hi part of BC
-		operandStr += "B"
-	case "C":
-		// 8-bit Lo part of BC
-		// or
-		// Condition code: Execute if C is set.
-		operandStr += "C"
-	case "DE":
-		// 16-bit register
-		operandStr += "DE"
-	case "D":
-		// 8-bit Hi part of DE
-		operandStr += "D"
-	case "E":
-		// 8-bit Lo part of DE
-		operandStr += "E"
-	case "HL":
-		// 8-bit Hi part of HL
-		operandStr += "HL"
-	case "H":
-		// 8-bit Hi part of HL
-		operandStr += "H"
-	case "L":
-		// 8-bit Lo part of HL
-		operandStr += "L"
-	case "SP":
-		// 16-bit register (Stack Pointer)
-		operandStr += "SP"
-	case "n8":
-		// immediate 8-bit data
-		return unsigned8BitData(data, programCounter, operand)
-	case "n16":
-		// immediate little-endian 16-bit data
-		return littleEndian16BitAddressOrData(data, programCounter, operand)
-	case "a8":
-		// means 8-bit unsigned data, which is added to $FF00 in certain instructions to create a 16-bit address in HRAM (High RAM)
-		return unsigned8BitData(data, programCounter, operand)
-	case "a16":
-		// little-endian 16-bit address
-		return littleEndian16BitAddressOrData(data, programCounter, operand)
-	case "e8":
-		// 8-bit signed data (offset)
-		return signed8BitData(data, programCounter, operand)
-	case "Z":
-		// Condition code: Execute if Z is set.
-		operandStr += "Z"
-	case "NZ":
-		// Condition code: Execute if Z is not set.
-		operandStr += "NZ"
-	case "NC":
-		// Condition code: Execute if C is not set.
-		operandStr += "NC"
-	case "$00":
-		operandStr += "0x00(H)"
-	case "$08":
-		operandStr += "0x08(H)"
-	case "$10":
-		operandStr += "0x10(H)"
-	case "$18":
-		operandStr += "0x18(H)"
-	case "$20":
-		operandStr += "0x20(H)"
-	case "$28":
-		operandStr += "0x28(H)"
-	case "$30":
-		operandStr += "0x30(H)"
-	case "$38":
-		operandStr += "0x38(H)"
-	default:
-		log.Panicf("unknown operand name: {%s}", operand.Name)
+	if target < 0x4000 {
+		return BankAddress{0, target}, true
 	}
-	return programCounter, operandStr
+	return BankAddress{i.TargetBank, target}, i.TargetBank != UnknownBank
 }
 
-func readOperands(data []byte, programCounter uint16, operands []Operand) (uint16, string) {
-	operandStr := ""
-	for i, operand := range operands {
-		newProgramCounter, str := handleOperand(data, programCounter, &operand)
-		programCounter = newProgramCounter
-		if !operand.Immediate {
-			str = fmt.Sprintf("[%s]", str)
-		}
-		if i < len(operands)-1 {
-			str = fmt.Sprintf("%s, ", str)
+// FallsThrough reports whether execution may continue with the next instruction.
+func (i *Instruction) FallsThrough() bool {
+	switch i.Flow {
+	case FlowJump, FlowReturn, FlowIllegal:
+		return i.Conditional
+	}
+	return !i.Data
+}
+
+var registerNames = map[string]bool{
+	"A": true, "B": true, "C": true, "D": true, "E": true, "H": true, "L": true,
+	"AF": true, "BC": true, "DE": true, "HL": true, "SP": true,
+}
+
+var conditions = map[string]bool{"Z": true, "NZ": true, "C": true, "NC": true}
+
+var controlFlows = map[string]ControlFlow{
+	"JP": FlowJump, "JR": FlowJump,
+	"CALL": FlowCall, "RST": FlowCall,
+	"RET": FlowReturn, "RETI": FlowReturn,
+	"HALT": FlowStop, "STOP": FlowStop,
+}
+
+// isCondition distinguishes the condition C from the register C: conditions are the first operand of a transfer.
+func isCondition(opcode Opcode, index int) bool {
+	_, transfer := controlFlows[opcode.Mnemonic]
+	return transfer && index == 0 && conditions[opcode.Operands[index].Name] &&
+		(len(opcode.Operands) == 2 || opcode.Mnemonic == "RET")
+}
+
+// decodeOperand decodes the operand, whose bytes start at data[0]. The end address is needed to resolve relative jumps.
+func decodeOperand(opcode Opcode, index int, data []byte, end uint16) OperandValue {
+	operand := opcode.Operands[index]
+	value := OperandValue{
+		Name:      operand.Name,
+		Indirect:  !operand.Immediate,
+		Increment: operand.Increment,
+		Decrement: operand.Decrement,
+	}
+
+	switch name := operand.Name; {
+	case isCondition(opcode, index):
+		value.Kind = OperandCondition
+	case registerNames[name]:
+		value.Kind = OperandRegister
+	case name == "n8":
+		value.Kind = OperandImmediate
+		value.Value = uint16(data[0])
+	case name == "n16":
+		value.Kind = OperandImmediate
+		value.Value = binary.LittleEndian.Uint16(data)
+	case name == "a8":
+		value.Kind = OperandAddress
+		value.Value = 0xFF00 | uint16(data[0])
+	case name == "a16":
+		value.Kind = OperandAddress
+		value.Value = binary.LittleEndian.Uint16(data)
+	case name == "e8":
+		value.Offset = int8(data[0])
+		value.Kind = OperandOffset
+		if opcode.Mnemonic == "JR" {
+			value.Kind = OperandRelative
+			value.Value = end + uint16(value.Offset)
 		}
-		operandStr += str
+	case len(name) == 1 && name[0] >= '0' && name[0] <= '7':
+		value.Kind = OperandBit
+		value.Value = uint16(name[0] - '0')
+	case strings.HasPrefix(name, "$"):
+		// RST vectors are named $00 to $38
+		value.Kind = OperandVector
+		value.Value = uint16(hexDigit(name[1]))<<4 | uint16(hexDigit(name[2]))
 	}
-	return programCounter, fmt.Sprintf(" %s", operandStr)
+	return value
 }
 
-type Instruction struct {
-	Line         string
-	AddressStart uint16
-	AddressEnd   uint16
+func hexDigit(c byte) byte {
+	if c >= 'A' {
+		return c - 'A' + 10
+	}
+	return c - '0'
 }
 
-func parseOpcode(data []byte, programCounter uint16, list *OpcodeList) (uint16, Opcode) {
-	prefixed := isPrefixed(data[programCounter])
+func isIllegal(opcode Opcode) bool {
+	return strings.HasPrefix(opcode.Mnemonic, "ILLEGAL")
+}
 
-	var opcode Opcode
-	if !prefixed {
-		opcode = list.UnPrefixed[ByteKey{data[programCounter]}]
-	} else {
-		programCounter++
-		opcode = list.UnPrefixed[ByteKey{data[programCounter]}]
+// parseOpcode looks up the opcode at the program counter. It reports false for the illegal opcodes and for a 0xCB
+// prefix at the end of the data.
+func parseOpcode(data []byte, programCounter int, list *OpcodeList) (Opcode, bool) {
+	b := data[programCounter]
+	if !isPrefixed(b) {
+		opcode, ok := list.UnPrefixed[ByteKey{Value: b}]
+		return opcode, ok && !isIllegal(opcode)
+	}
+	if programCounter+1 >= len(data) {
+		return Opcode{}, false
 	}
-	programCounter++
-	return programCounter, opcode
+	opcode, ok := list.CbPrefixed[ByteKey{Value: data[programCounter+1]}]
+	return opcode, ok
 }
 
-func Disassemble(data []byte, programCounter uint16, list *OpcodeList) []Instruction {
-	instructions := make([]Instruction, 0, len(data))
+// decodeInstruction decodes the instruction at data[start], addressed as address.
+func decodeInstruction(data []byte, start int, address uint16, list *OpcodeList) Instruction {
+	opcode, ok := parseOpcode(data, start, list)
+	end := start + opcode.Bytes
 
-	for int(programCounter) < len(data) {
-		i := Instruction{}
-		i.AddressStart = programCounter
+	i := Instruction{AddressStart: address}
+	switch {
+	case !ok:
+		end = start + 1
+		i.Data = true
+		if !isPrefixed(data[start]) {
+			i.Flow = FlowIllegal
+		}
+	case end > len(data):
+		end = len(data)
+		i.Data = true
+	default:
+		i.Opcode = opcode
+		i.Prefixed = isPrefixed(data[start])
+		i.Flow = controlFlows[opcode.Mnemonic]
 
-		newProgramCounter, opcode := parseOpcode(data, programCounter, list)
-		programCounter = newProgramCounter
-		i.Line += opcode.Mnemonic
+		operandStart := start + 1
+		if i.Prefixed {
+			operandStart++
+		}
+		addressEnd := address + uint16(opcode.Bytes)
+		for index, operand := range opcode.Operands {
+			value := decodeOperand(opcode, index, data[operandStart:end], addressEnd)
+			i.Conditional = i.Conditional || value.Kind == OperandCondition
+			i.Operands = append(i.Operands, value)
+			operandStart += operand.Bytes
+		}
+	}
+	i.Bytes = data[start:end]
+	i.AddressEnd = address + uint16(end-start)
+	return i
+}
 
-		newProgramCounter, str := readOperands(data, programCounter, opcode.Operands)
-		programCounter = newProgramCounter
-		i.Line += str
+// Disassemble decodes the rom data linearly, starting at the offset given by the program counter. Instructions are
+// located by bank, an instruction never crosses the end of a bank. Illegal opcodes and instructions cut off by the end
+// of a bank are emitted as Data entries.
+func Disassemble(data []byte, programCounter uint16, list *OpcodeList) []Instruction {
+	instructions := make([]Instruction, 0, len(data)/2)
 
-		i.AddressEnd = programCounter
+	for start := int(programCounter); start < len(data); {
+		location := romLocation(start)
+		bankEnd := min((start/romBankSize+1)*romBankSize, len(data))
+		i := decodeInstruction(data[:bankEnd], start, location.Address, list)
+		i.Bank = location.Bank
+		// code in bank 0 sees the initial bank 1, code in other banks its own bank
+		i.TargetBank = max(location.Bank, 1)
 		instructions = append(instructions, i)
+		start += len(i.Bytes)
 	}
 
 	return instructions
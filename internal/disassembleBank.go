@@ -0,0 +1,317 @@
+package internal
+
+import "fmt"
+
+// BankAddress locates a byte of a banked rom by its bank and the address it is mapped to.
+type BankAddress struct {
+	Bank    uint16
+	Address uint16
+}
+
+// UnknownBank is the bank of locations in 0x4000-0x7FFF whose bank could not be resolved.
+const UnknownBank = 0xFFFF
+
+// String formats the location as bank:address, e.g. 05:4123, or ??:4123 if the bank is unknown.
+func (a BankAddress) String() string {
+	if a.Bank == UnknownBank {
+		return fmt.Sprintf("??:%04X", a.Address)
+	}
+	return fmt.Sprintf("%02X:%04X", a.Bank, a.Address)
+}
+
+// romLocation returns where the byte at the offset into the rom file is mapped to: bank 0 at 0x0000-0x3FFF, all
+// other banks at 0x4000-0x7FFF.
+func romLocation(offset int) BankAddress {
+	bank := offset / romBankSize
+	if bank == 0 {
+		return BankAddress{0, uint16(offset)}
+	}
+	return BankAddress{uint16(bank), 0x4000 + uint16(offset%romBankSize)}
+}
+
+// romOffset returns the offset into the rom file of the address, with the bank mapped to 0x4000-0x7FFF.
+func romOffset(bank int, address uint16) int {
+	if address < 0x4000 {
+		return int(address)
+	}
+	return bank*romBankSize + int(address-0x4000)
+}
+
+// unknownValue marks register values not known to the bank tracker.
+const unknownValue = -1
+
+// bankController is the family of memory bank controller, it decides how writes to 0x0000-0x7FFF select the ROM bank.
+type bankController int
+
+const (
+	controllerNone bankController = iota // rom only, bank 1 is always mapped
+	controllerMBC1
+	controllerMBC2
+	controllerMBC3
+	controllerMBC5
+	controllerMBC7
+	controllerHuC1
+	controllerUnknown // the bank registers are not modeled, the mapped bank is never known
+)
+
+// bankSwitching describes how the memory bank controller of the rom selects the bank mapped to 0x4000-0x7FFF.
+type bankSwitching struct {
+	banks      int
+	controller bankController
+}
+
+func newBankSwitching(rom []byte) bankSwitching {
+	switching := bankSwitching{banks: max(len(rom)/romBankSize, 1), controller: controllerUnknown}
+	switch {
+	case len(rom) <= cartridgeTypeAddress:
+		// code without a cartridge header
+		switching.controller = controllerNone
+		return switching
+	case isMMM01(rom):
+		return switching
+	}
+	switch rom[cartridgeTypeAddress] {
+	case 0x00, 0x08, 0x09:
+		switching.controller = controllerNone
+	case 0x01, 0x02, 0x03:
+		switching.controller = controllerMBC1
+	case 0x05, 0x06:
+		switching.controller = controllerMBC2
+	case 0x0F, 0x10, 0x11, 0x12, 0x13:
+		switching.controller = controllerMBC3
+	case 0x19, 0x1A, 0x1B, 0x1C, 0x1D, 0x1E:
+		switching.controller = controllerMBC5
+	case 0x22:
+		switching.controller = controllerMBC7
+	case 0xFF:
+		switching.controller = controllerHuC1
+	}
+	// MBC6 maps two 8 KiB banks, HuC3, TAMA5 and MMM01 are not modeled either
+	return switching
+}
+
+// initialBank returns the bank mapped to 0x4000-0x7FFF after reset.
+func (s bankSwitching) initialBank() int {
+	if s.controller == controllerUnknown {
+		return unknownValue
+	}
+	return 1
+}
+
+// bankState follows the values of the 8-bit registers along a path of instructions, to resolve writes of known values
+// to the ROM bank register (0x2000-0x3FFF) and the addresses accessed through register pairs.
+type bankState struct {
+	// registers holds the values of the registers indexed by registerIndex, unknownValue if they are not known
+	registers [7]int
+	// bank is mapped to 0x4000-0x7FFF, unknownValue if it can't be resolved
+	bank int
+}
+
+// registerIndex indexes the registers tracked by bankState. F, SP and PC are not tracked.
+var registerIndex = map[string]int{"A": 0, "B": 1, "C": 2, "D": 3, "E": 4, "H": 5, "L": 6}
+
+// newBankState returns the state at a jump target with the bank mapped and all registers unknown.
+func newBankState(bank int) bankState {
+	s := bankState{bank: bank}
+	s.forget()
+	return s
+}
+
+// forget marks all registers unknown.
+func (s *bankState) forget() {
+	for k := range s.registers {
+		s.registers[k] = unknownValue
+	}
+}
+
+// value returns the value of the 8-bit register or register pair, unknownValue if it is not known or not tracked.
+func (s *bankState) value(name string) int {
+	if len(name) == 2 {
+		high, low := s.value(name[:1]), s.value(name[1:])
+		if high == unknownValue || low == unknownValue {
+			return unknownValue
+		}
+		return high<<8 | low
+	}
+	if index, ok := registerIndex[name]; ok {
+		return s.registers[index]
+	}
+	return unknownValue
+}
+
+// set sets the value of the 8-bit register or register pair, for AF only A is tracked.
+func (s *bankState) set(name string, value int) {
+	if len(name) == 2 {
+		high, low := unknownValue, unknownValue
+		if value != unknownValue {
+			high, low = value>>8&0xFF, value&0xFF
+		}
+		s.set(name[:1], high)
+		s.set(name[1:], low)
+		return
+	}
+	if index, ok := registerIndex[name]; ok {
+		s.registers[index] = value
+	}
+}
+
+// add adds the delta to the register or register pair if its value is known.
+func (s *bankState) add(name string, delta int) {
+	value := s.value(name)
+	if value == unknownValue {
+		return
+	}
+	mask := 0xFF
+	if len(name) == 2 {
+		mask = 0xFFFF
+	}
+	s.set(name, (value+delta)&mask)
+}
+
+// address returns the memory address an indirect operand accesses, unknownValue if it is not known. [C] accesses
+// 0xFF00+C.
+func (s *bankState) address(operand OperandValue) int {
+	switch {
+	case !operand.Indirect:
+		return unknownValue
+	case operand.Kind == OperandAddress:
+		return int(operand.Value)
+	case operand.Name == "C":
+		if c := s.value("C"); c != unknownValue {
+			return 0xFF00 | c
+		}
+		return unknownValue
+	}
+	return s.value(operand.Name)
+}
+
+// operandValue returns the value an operand reads, unknownValue if it is not known.
+func (s *bankState) operandValue(operand OperandValue) int {
+	switch {
+	case operand.Kind == OperandImmediate:
+		return int(operand.Value)
+	case operand.Kind == OperandRegister && !operand.Indirect && !operand.Increment:
+		return s.value(operand.Name)
+	}
+	return unknownValue
+}
+
+// bankRegister reports whether a write to the address selects the ROM bank.
+func (s bankSwitching) bankRegister(address uint16) bool {
+	switch s.controller {
+	case controllerNone, controllerUnknown:
+		return false
+	case controllerMBC2:
+		// bit 8 of the address selects the ROM bank register instead of the RAM enable register
+		return address < 0x4000 && address&0x0100 != 0
+	}
+	// MBC1 selects bank bits 5-6 or the RAM bank at 0x4000-0x5FFF depending on its mode, which is not tracked
+	return address >= 0x2000 && address < 0x4000
+}
+
+// selectBank applies a write of the value to the address in 0x0000-0x7FFF to the mapped bank. The value is masked to
+// the width of the bank register, bank 0 is replaced by bank 1 where the controller does so.
+func (s *bankState) selectBank(switching bankSwitching, address uint16, value int) {
+	if !switching.bankRegister(address) {
+		return
+	}
+	if value == unknownValue {
+		s.bank = unknownValue
+		return
+	}
+
+	var bank int
+	switch switching.controller {
+	case controllerMBC1:
+		bank = s.withKeptBits(switching, max(value&0b1_1111, 1), 0b110_0000)
+	case controllerMBC2:
+		bank = max(value&0b1111, 1)
+	case controllerMBC3:
+		bank = max(value&0b111_1111, 1)
+	case controllerMBC5:
+		// the low 8 bits are written to 0x2000-0x2FFF, bit 8 to 0x3000-0x3FFF, bank 0 may be mapped
+		if address < 0x3000 {
+			bank = s.withKeptBits(switching, value&0xFF, 0x100)
+		} else {
+			bank = s.withKeptBits(switching, (value&0b1)<<8, 0xFF)
+		}
+	case controllerMBC7:
+		bank = value & 0b111_1111
+	case controllerHuC1:
+		bank = max(value&0b11_1111, 1)
+	}
+	if bank != unknownValue {
+		bank %= switching.banks
+	}
+	s.bank = bank
+}
+
+// withKeptBits combines the bits written to a bank register with the bits of the mapped bank set by other registers.
+// Kept bits too high to select a bank of the rom are ignored, otherwise the result is unknown if the mapped bank is.
+func (s *bankState) withKeptBits(switching bankSwitching, written, keep int) int {
+	if s.bank != unknownValue {
+		return written | s.bank&keep
+	}
+	if keep&-keep < switching.banks {
+		return unknownValue
+	}
+	return written
+}
+
+// accumulatorOperations change A without naming it as an operand.
+var accumulatorOperations = map[string]bool{"RLCA": true, "RRCA": true, "RLA": true, "RRA": true, "CPL": true, "DAA": true}
+
+// update applies the effect of the instruction on the registers and the mapped bank.
+func (s *bankState) update(i *Instruction, switching bankSwitching) {
+	mnemonic := i.Opcode.Mnemonic
+	if i.Flow == FlowCall {
+		// the callee may change any register
+		s.forget()
+		return
+	}
+	if accumulatorOperations[mnemonic] {
+		s.set("A", unknownValue)
+		return
+	}
+	if len(i.Operands) == 0 {
+		return
+	}
+
+	first := i.Operands[0]
+	switch {
+	case mnemonic == "LD" && len(i.Operands) == 2 && first.Indirect:
+		if address := s.address(first); address != unknownValue {
+			s.selectBank(switching, uint16(address), s.operandValue(i.Operands[1]))
+		}
+	case (mnemonic == "LD" || mnemonic == "LDH") && len(i.Operands) == 2 && first.Kind == OperandRegister:
+		s.set(first.Name, s.operandValue(i.Operands[1]))
+	case mnemonic == "XOR" && first.Name == "A" && i.Operands[1].Name == "A" && !i.Operands[1].Indirect:
+		s.set("A", 0)
+	case (mnemonic == "INC" || mnemonic == "DEC") && first.Kind == OperandRegister && !first.Indirect:
+		if mnemonic == "INC" {
+			s.add(first.Name, 1)
+		} else {
+			s.add(first.Name, -1)
+		}
+	case mnemonic == "RES" || mnemonic == "SET":
+		// the bit index is the first operand
+		if target := i.Operands[1]; !target.Indirect {
+			s.set(target.Name, unknownValue)
+		}
+	case first.Kind == OperandRegister && !first.Indirect && mnemonic != "CP" && mnemonic != "PUSH" &&
+		mnemonic != "JP":
+		s.set(first.Name, unknownValue)
+	}
+
+	// LD [HL+], A and LD A, [HL-] step HL after the access
+	for _, operand := range i.Operands {
+		if operand.Name == "HL" && operand.Indirect {
+			if operand.Increment {
+				s.add("HL", 1)
+			}
+			if operand.Decrement {
+				s.add("HL", -1)
+			}
+		}
+	}
+}
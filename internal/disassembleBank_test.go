@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBankAddress(t *testing.T) {
+	assert.Equal(t, "05:4123", BankAddress{5, 0x4123}.String())
+	assert.Equal(t, "1FF:7FFF", BankAddress{0x1FF, 0x7FFF}.String())
+
+	assert.Equal(t, BankAddress{0, 0x3FFF}, romLocation(0x3FFF))
+	assert.Equal(t, BankAddress{5, 0x4123}, romLocation(5*romBankSize+0x0123))
+	// the last byte of an 8 MiB rom
+	assert.Equal(t, BankAddress{511, 0x7FFF}, romLocation(8<<20-1))
+	assert.Equal(t, 5*romBankSize+0x0123, romOffset(5, 0x4123))
+	assert.Equal(t, 0x0123, romOffset(5, 0x0123))
+}
+
+func TestDisassembleBanked(t *testing.T) {
+	opcodes, err := ParseOpcodes()
+	assert.NoError(t, err)
+
+	rom := newTestROM(0x01, 0x00, 4)
+	copy(rom[0x0000:], []byte{0xC3, 0x00, 0x40}) // JP 0x4000 in the initial bank 1
+	copy(rom[0x0100:], []byte{
+		0x3E, 0x03, // LD A, 0x03
+		0xEA, 0x00, 0x20, // LD [0x2000], A
+		0xCD, 0x00, 0x40, // CALL 0x4000 in bank 3
+		0x21, 0x00, 0x20, // LD HL, 0x2000
+		0x36, 0x02, // LD [HL], 0x02
+		0xC3, 0x02, 0x40, // JP 0x4002 in bank 2
+	})
+	copy(rom[1*romBankSize:], []byte{0xC9})                               // RET
+	copy(rom[2*romBankSize+2:], []byte{0x18, 0xFE})                       // JR 0x4002
+	copy(rom[3*romBankSize:], []byte{0x3E, 0x00, 0x18, 0x01, 0xFF, 0xC9}) // LD A, 0x00; JR 0x4005; RET
+
+	instructions := DisassembleRecursive(rom, []uint16{0x0000, entryPointAddress}, opcodes)
+
+	lines := make(map[string]string)
+	for _, i := range instructions {
+		if !i.Data {
+			lines[i.Location().String()] = FormatInstruction(i)
+		}
+	}
+	assert.Equal(t, "RET", lines["01:4000"])
+	assert.Equal(t, "JR -2 (0xFE)", lines["02:4002"])
+	assert.Equal(t, "LD A, 0x00", lines["03:4000"])
+	assert.Equal(t, "RET", lines["03:4005"])
+	assert.NotContains(t, lines, "03:4004")
+
+	// the linear mode locates instructions by bank as well
+	instructions = Disassemble(rom, 0, opcodes)
+	last := instructions[len(instructions)-1]
+	assert.Equal(t, BankAddress{3, 0x7FFF}, last.Location())
+	assert.Equal(t, uint16(0x8000), last.AddressEnd)
+}
+
+func TestBankSwitchingRegisters(t *testing.T) {
+	selectBank := func(cartridgeType uint8, banks int, address uint16, value int) int {
+		switching := newBankSwitching(newTestROM(cartridgeType, 0x00, banks))
+		state := newBankState(switching.initialBank())
+		state.selectBank(switching, address, value)
+		return state.bank
+	}
+
+	// MBC1 keeps 5 bits and maps bank 0x20 to 1
+	assert.Equal(t, 1, selectBank(0x01, 64, 0x2000, 0x20))
+	assert.Equal(t, 0x13, selectBank(0x01, 64, 0x3FFF, 0x13))
+	// MBC2 keeps 4 bits and only selects the bank with bit 8 of the address set
+	assert.Equal(t, 1, selectBank(0x05, 16, 0x2000, 0x03))
+	assert.Equal(t, 3, selectBank(0x05, 16, 0x2100, 0x13))
+	// MBC3 keeps 7 bits
+	assert.Equal(t, 0x05, selectBank(0x13, 128, 0x2000, 0x85))
+	// MBC5 selects bank 0 and takes bit 8 from 0x3000-0x3FFF
+	assert.Equal(t, 0, selectBank(0x19, 512, 0x2000, 0x00))
+	assert.Equal(t, 0x101, selectBank(0x19, 512, 0x3000, 0x01))
+	// unknown values and controllers that are not modeled leave the bank unknown
+	assert.Equal(t, unknownValue, selectBank(0x13, 128, 0x2000, unknownValue))
+	assert.Equal(t, unknownValue, selectBank(0xFE, 128, 0x2000, 0x02))
+}
+
+func TestDisassembleUnknownBank(t *testing.T) {
+	opcodes, err := ParseOpcodes()
+	assert.NoError(t, err)
+
+	// HuC3 bank switches are not modeled
+	rom := newTestROM(0xFE, 0x00, 4)
+	copy(rom[entryPointAddress:], []byte{0xCD, 0x00, 0x40, 0x18, 0xFE}) // CALL 0x4000; JR 0x0103
+	copy(rom[1*romBankSize:], []byte{0xC9})                             // RET
+
+	instructions := DisassembleRecursive(rom, []uint16{entryPointAddress}, opcodes)
+	for _, i := range instructions {
+		if i.Location() == (BankAddress{0, entryPointAddress}) {
+			assert.Equal(t, uint16(UnknownBank), i.TargetBank)
+			_, ok := i.TargetLocation()
+			assert.False(t, ok)
+		}
+		if i.Bank == 1 {
+			assert.True(t, i.Data)
+		}
+	}
+	assert.Equal(t, "??:4000", BankAddress{UnknownBank, 0x4000}.String())
+}
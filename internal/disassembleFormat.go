@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// formatDataBytes formats bytes that are no complete instruction as a db directive.
+func formatDataBytes(data []byte) string {
+	values := make([]string, len(data))
+	for i, b := range data {
+		values[i] = fmt.Sprintf("0x%.2X", b)
+	}
+	return "db " + strings.Join(values, ", ")
+}
+
+// addressLocation returns the location an address operand of the instruction refers to. Memory accesses to
+// 0x4000-0x7FFF go to the bank mapped when the instruction runs, RAM and I/O accesses are located in bank 0.
+func addressLocation(i Instruction, address uint16) BankAddress {
+	if target, ok := i.TargetLocation(); ok {
+		return target
+	}
+	if address >= 0x4000 && address < 0x8000 {
+		return BankAddress{i.TargetBank, address}
+	}
+	return BankAddress{0, address}
+}
+
+func formatOperand(i Instruction, operand OperandValue, symbols *Symbols) string {
+	var str string
+	switch operand.Kind {
+	case OperandRegister, OperandCondition, OperandBit:
+		str = operand.Name
+		if operand.Increment {
+			str += "+"
+		}
+		if operand.Decrement {
+			str += "-"
+		}
+	case OperandImmediate:
+		if operand.Name == "n16" {
+			str = fmt.Sprintf("0x%.4X", operand.Value)
+		} else {
+			str = fmt.Sprintf("0x%.2X", operand.Value)
+		}
+	case OperandAddress, OperandRelative:
+		if name, ok := symbols.Lookup(addressLocation(i, operand.Value)); ok {
+			str = name
+			break
+		}
+		if operand.Kind == OperandRelative {
+			str = fmt.Sprintf("%d (0x%.2X)", operand.Offset, uint8(operand.Offset))
+		} else if operand.Name == "a8" {
+			str = fmt.Sprintf("0x%.2X", uint8(operand.Value))
+		} else {
+			str = fmt.Sprintf("0x%.4X", operand.Value)
+		}
+	case OperandOffset:
+		str = fmt.Sprintf("%d (0x%.2X)", operand.Offset, uint8(operand.Offset))
+	case OperandVector:
+		str = fmt.Sprintf("0x%.2X(H)", operand.Value)
+	}
+	if operand.Indirect {
+		str = fmt.Sprintf("[%s]", str)
+	}
+	return str
+}
+
+// FormatInstruction renders the instruction as text, e.g. "LD [0xC31C], A".
+func FormatInstruction(i Instruction) string {
+	return formatInstruction(i, nil)
+}
+
+func formatInstruction(i Instruction, symbols *Symbols) string {
+	if i.Data {
+		return formatDataBytes(i.Bytes)
+	}
+
+	operands := make([]string, 0, len(i.Operands))
+	for index := 0; index < len(i.Operands); index++ {
+		operand := i.Operands[index]
+		// LD HL, SP+e8 adds the following offset to SP
+		if operand.Kind == OperandRegister && operand.Increment && !operand.Indirect && index+1 < len(i.Operands) {
+			index++
+			operands = append(operands, fmt.Sprintf("%s%+d", operand.Name, i.Operands[index].Offset))
+			continue
+		}
+		operands = append(operands, formatOperand(i, operand, symbols))
+	}
+
+	if len(operands) == 0 {
+		return i.Opcode.Mnemonic
+	}
+	return i.Opcode.Mnemonic + " " + strings.Join(operands, ", ")
+}
@@ -0,0 +1,270 @@
+package internal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// bytesPerDataLine is the number of bytes written per db line.
+const bytesPerDataLine = 16
+
+// RGBDSOptions configures the assembly source written by WriteRGBDS.
+type RGBDSOptions struct {
+	// ROMFile is the path INCBIN directives read large data blocks from. Without a path all data is written as db.
+	ROMFile string
+	// IncbinThreshold is the minimum length of data written as INCBIN.
+	IncbinThreshold int
+	// Symbols name the labels. Labels without a symbol, or with a name that is no plain identifier, are generated.
+	Symbols *Symbols
+}
+
+// isIdentifier reports whether the name can be used as a global label.
+func isIdentifier(name string) bool {
+	for k, c := range name {
+		letter := c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c == '_'
+		if !letter && (k == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// labelName returns the name of the label for the location.
+func (r *rgbdsWriter) labelName(location BankAddress) string {
+	if name, ok := r.options.Symbols.Lookup(location); ok && isIdentifier(name) {
+		return name
+	}
+	return fmt.Sprintf("L%02X_%04X", location.Bank, location.Address)
+}
+
+// rgbdsWriter renders instructions as RGBDS source. Labels are only generated for locations an instruction or a data
+// block starts at, all other references are written as numbers.
+type rgbdsWriter struct {
+	w       *bufio.Writer
+	options RGBDSOptions
+	code    map[BankAddress]bool
+	labels  map[BankAddress]bool
+}
+
+// WriteRGBDS writes the instructions as RGBDS assembly source with a section per bank. The instructions have to cover
+// the whole rom, as returned by DisassembleRecursive or by Disassemble starting at 0. Instructions rgbasm would encode
+// differently or reject are written as db: HALT, which rgbasm 0.5 follows with a nop, STOP, and JR with a target
+// outside 0x0000-0xFFFF. The source is meant to be assembled with rgbasm 0.6 or newer; rgbasm 0.5 additionally
+// optimizes ld to ldh unless -L is given.
+func WriteRGBDS(w io.Writer, instructions []Instruction, options RGBDSOptions) error {
+	writer := &rgbdsWriter{
+		w:       bufio.NewWriter(w),
+		options: options,
+		code:    make(map[BankAddress]bool),
+		labels:  make(map[BankAddress]bool),
+	}
+	writer.collectLabels(instructions)
+
+	section := -1
+	for _, i := range instructions {
+		if int(i.Bank) != section {
+			section = int(i.Bank)
+			writer.writeSection(i.Bank)
+		}
+		if writer.labels[i.Location()] {
+			fmt.Fprintf(writer.w, "%s:\n", writer.labelName(i.Location()))
+		}
+		if i.Data {
+			writer.writeData(i)
+		} else {
+			fmt.Fprintf(writer.w, "\t%s\n", writer.formatInstruction(i))
+		}
+	}
+	return writer.w.Flush()
+}
+
+// collectLabels generates labels for the targets of jumps and calls, for the entries of jump tables and for symbols.
+func (r *rgbdsWriter) collectLabels(instructions []Instruction) {
+	for _, i := range instructions {
+		if !i.Data {
+			r.code[i.Location()] = true
+		}
+		if _, ok := r.options.Symbols.Lookup(i.Location()); ok {
+			r.labels[i.Location()] = true
+		}
+	}
+	for _, i := range instructions {
+		if target, ok := i.TargetLocation(); ok && r.code[target] {
+			r.labels[target] = true
+		}
+		for _, entry := range r.jumpTable(i) {
+			r.labels[entry] = true
+		}
+	}
+}
+
+// jumpTable returns the words at the start of a data block that point to instructions, as a table of code addresses
+// usually follows the code dispatching through it. Fewer than two entries are not taken as a table.
+func (r *rgbdsWriter) jumpTable(i Instruction) []BankAddress {
+	if !i.Data {
+		return nil
+	}
+	var entries []BankAddress
+	for k := 0; k+1 < len(i.Bytes); k += 2 {
+		address := binary.LittleEndian.Uint16(i.Bytes[k:])
+		if address == 0x0000 {
+			// tables are often terminated by a null word, padding is usually zero as well
+			break
+		}
+		entry := BankAddress{0, address}
+		if address >= 0x4000 {
+			if i.Bank == 0 {
+				// the bank mapped when the table is used is not known
+				break
+			}
+			entry.Bank = i.Bank
+		}
+		if !r.code[entry] {
+			break
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) < 2 {
+		return nil
+	}
+	return entries
+}
+
+func (r *rgbdsWriter) writeSection(bank uint16) {
+	if bank == 0 {
+		fmt.Fprintf(r.w, "\nSECTION \"ROM Bank $000\", ROM0[$0000]\n\n")
+		return
+	}
+	fmt.Fprintf(r.w, "\nSECTION \"ROM Bank $%03X\", ROMX[$4000], BANK[$%X]\n\n", bank, bank)
+}
+
+func (r *rgbdsWriter) writeData(i Instruction) {
+	entries := r.jumpTable(i)
+	for _, entry := range entries {
+		fmt.Fprintf(r.w, "\tdw %s\n", r.labelName(entry))
+	}
+
+	data := i.Bytes[2*len(entries):]
+	if len(data) == 0 {
+		return
+	}
+	if r.options.ROMFile != "" && len(data) >= r.options.IncbinThreshold {
+		offset := romOffset(int(i.Bank), i.AddressStart) + 2*len(entries)
+		fmt.Fprintf(r.w, "\tINCBIN %q, $%X, $%X\n", r.options.ROMFile, offset, len(data))
+		return
+	}
+	for start := 0; start < len(data); start += bytesPerDataLine {
+		r.writeBytes(data[start:min(start+bytesPerDataLine, len(data))])
+	}
+}
+
+func (r *rgbdsWriter) writeBytes(data []byte) {
+	fmt.Fprintf(r.w, "\t%s\n", rgbdsBytes(data))
+}
+
+// rgbdsBytes formats the bytes as a db directive, e.g. "db $10, $00".
+func rgbdsBytes(data []byte) string {
+	values := make([]string, len(data))
+	for k, b := range data {
+		values[k] = fmt.Sprintf("$%02X", b)
+	}
+	return "db " + strings.Join(values, ", ")
+}
+
+// relativeInRange reports whether the target of a JR lies within 0x0000-0xFFFF without wrapping around, rgbasm
+// rejects other targets.
+func relativeInRange(i Instruction) bool {
+	for _, operand := range i.Operands {
+		if operand.Kind == OperandRelative {
+			target := int(i.AddressEnd) + int(operand.Offset)
+			return target >= 0 && target <= 0xFFFF
+		}
+	}
+	return true
+}
+
+// reference returns the label of the target if there is one and it may be used by the instruction, otherwise the
+// address as a number.
+func (r *rgbdsWriter) reference(i Instruction, address uint16) string {
+	target, ok := i.TargetLocation()
+	// JR can only reach labels of its own section
+	if ok && r.labels[target] && (i.Opcode.Mnemonic != "JR" || target.Bank == i.Bank) {
+		return r.labelName(target)
+	}
+	return fmt.Sprintf("$%04X", address)
+}
+
+func (r *rgbdsWriter) formatOperand(i Instruction, operand OperandValue) string {
+	var str string
+	switch operand.Kind {
+	case OperandRegister, OperandCondition, OperandBit:
+		str = strings.ToLower(operand.Name)
+		if operand.Increment {
+			str += "+"
+		}
+		if operand.Decrement {
+			str += "-"
+		}
+	case OperandImmediate:
+		if operand.Name == "n16" {
+			str = fmt.Sprintf("$%04X", operand.Value)
+		} else {
+			str = fmt.Sprintf("$%02X", operand.Value)
+		}
+	case OperandAddress:
+		if i.Flow == FlowJump || i.Flow == FlowCall {
+			str = r.reference(i, operand.Value)
+		} else {
+			str = fmt.Sprintf("$%04X", operand.Value)
+		}
+	case OperandRelative:
+		str = r.reference(i, operand.Value)
+	case OperandOffset:
+		str = fmt.Sprintf("%d", operand.Offset)
+	case OperandVector:
+		str = fmt.Sprintf("$%02X", operand.Value)
+	}
+	if operand.Indirect {
+		str = fmt.Sprintf("[%s]", str)
+	}
+	return str
+}
+
+// formatInstruction renders an instruction in RGBDS syntax, e.g. "ldh [$FF44], a".
+func (r *rgbdsWriter) formatInstruction(i Instruction) string {
+	mnemonic := strings.ToLower(i.Opcode.Mnemonic)
+	switch {
+	case mnemonic == "stop":
+		// assemblers differ in the byte following STOP, so it is written as data
+		return rgbdsBytes(i.Bytes)
+	case mnemonic == "halt":
+		// rgbasm 0.5 inserts a nop after halt unless -h is given
+		return rgbdsBytes(i.Bytes)
+	case mnemonic == "jr" && !relativeInRange(i):
+		return rgbdsBytes(i.Bytes)
+	case mnemonic == "ld" && len(i.Operands) == 2 && (i.Operands[0].Name == "C" && i.Operands[0].Indirect ||
+		i.Operands[1].Name == "C" && i.Operands[1].Indirect):
+		// LD [C], A and LD A, [C] access 0xFF00+C
+		mnemonic = "ldh"
+	}
+
+	operands := make([]string, 0, len(i.Operands))
+	for index := 0; index < len(i.Operands); index++ {
+		operand := i.Operands[index]
+		// LD HL, SP+e8 adds the following offset to SP
+		if operand.Kind == OperandRegister && operand.Increment && !operand.Indirect && index+1 < len(i.Operands) {
+			index++
+			operands = append(operands, fmt.Sprintf("sp + %d", i.Operands[index].Offset))
+			continue
+		}
+		operands = append(operands, r.formatOperand(i, operand))
+	}
+
+	if len(operands) == 0 {
+		return mnemonic
+	}
+	return mnemonic + " " + strings.Join(operands, ", ")
+}
@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWriteRGBDS(t *testing.T) {
+	opcodes, err := ParseOpcodes()
+	assert.NoError(t, err)
+
+	rom := make([]byte, 0x0200)
+	copy(rom[0x0100:], []byte{
+		0x00,             // NOP
+		0xCD, 0x0E, 0x01, // CALL 0x010E
+		0x20, 0xFA, // JR NZ, 0x0100
+		0xE0, 0x44, // LDH [0xFF44], A
+		0xE2,       // LD [C], A
+		0xF8, 0xFD, // LD HL, SP-3
+		0x10, 0x00, // STOP
+		0xE9,                   // JP HL
+		0x22,                   // LD [HL+], A
+		0xC9,                   // RET
+		0x0E, 0x01, 0x00, 0x01, // jump table
+	})
+
+	var out bytes.Buffer
+	instructions := DisassembleRecursive(rom, []uint16{entryPointAddress}, opcodes)
+	err = WriteRGBDS(&out, instructions, RGBDSOptions{ROMFile: "game.gb", IncbinThreshold: 32})
+	assert.NoError(t, err)
+
+	assert.Equal(t, `
+SECTION "ROM Bank $000", ROM0[$0000]
+
+	INCBIN "game.gb", $0, $100
+L00_0100:
+	nop
+	call L00_010E
+	jr nz, L00_0100
+	ldh [$FF44], a
+	ldh [c], a
+	ld hl, sp + -3
+	db $10, $00
+	jp hl
+L00_010E:
+	ld [hl+], a
+	ret
+	dw L00_010E
+	dw L00_0100
+	INCBIN "game.gb", $114, $EC
+`, out.String())
+
+	// without a rom file data is written as db
+	out.Reset()
+	err = WriteRGBDS(&out, Disassemble(rom[:0x0104], 0, opcodes), RGBDSOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "\tnop\n\tnop\n")
+}
+
+func TestWriteRGBDSEncodingDifferences(t *testing.T) {
+	opcodes, err := ParseOpcodes()
+	assert.NoError(t, err)
+
+	rom := []byte{
+		0x18, 0xF0, // JR 0xFFF2, wrapping below 0x0000
+		0x76,       // HALT
+		0x18, 0x01, // JR 0x0006
+		0x00,       // NOP
+		0x18, 0xF8, // JR 0x0000
+	}
+
+	var out bytes.Buffer
+	err = WriteRGBDS(&out, Disassemble(rom, 0, opcodes), RGBDSOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `
+SECTION "ROM Bank $000", ROM0[$0000]
+
+L00_0000:
+	db $18, $F0
+	db $76
+	jr L00_0006
+	nop
+L00_0006:
+	jr L00_0000
+`, out.String())
+}
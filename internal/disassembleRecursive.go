@@ -0,0 +1,117 @@
+package internal
+
+// entryPointAddress is where the boot ROM hands over to the cartridge.
+// https://gbdev.io/pandocs/The_Cartridge_Header.html#0100-0103--entry-point
+const entryPointAddress = 0x0100
+
+// EntryPoints returns the addresses the hardware may start executing cartridge code at: the entry point, the RST
+// vectors 0x00-0x38 and the interrupt vectors 0x40-0x60.
+func EntryPoints() []uint16 {
+	entryPoints := []uint16{entryPointAddress}
+	for vector := uint16(0x00); vector <= 0x38; vector += 0x08 {
+		entryPoints = append(entryPoints, vector)
+	}
+	for vector := uint16(0x40); vector <= 0x60; vector += 0x08 {
+		entryPoints = append(entryPoints, vector)
+	}
+	return entryPoints
+}
+
+// overlaps reports whether an instruction at the offset would overlap instructions decoded before.
+func overlaps(covered []bool, offset, length int) bool {
+	for _, c := range covered[offset:min(offset+length, len(covered))] {
+		if c {
+			return true
+		}
+	}
+	return false
+}
+
+// disassemblyPath is an address to continue decoding at, with the bank mapped to 0x4000-0x7FFF at that point.
+type disassemblyPath struct {
+	address uint16
+	bank    int
+}
+
+// DisassembleRecursive decodes the code reachable from the entry points by following jumps, calls and restarts until
+// an unconditional transfer. Writes of known values to the ROM bank register select the bank later targets in
+// 0x4000-0x7FFF are resolved in, initially bank 1. Code in 0x4000-0x7FFF is not followed while the bank is unknown,
+// i.e. after writes of unknown values and for controllers whose bank registers are not modeled. All bytes not reached
+// are returned as Data entries, so the instructions cover the rom without gaps, ordered by bank and address.
+func DisassembleRecursive(data []byte, entryPoints []uint16, list *OpcodeList) []Instruction {
+	switching := newBankSwitching(data)
+
+	decoded := make(map[int]Instruction)
+	covered := make([]bool, len(data))
+	var work []disassemblyPath
+	for _, address := range entryPoints {
+		work = append(work, disassemblyPath{address, switching.initialBank()})
+	}
+
+	for len(work) > 0 {
+		path := work[len(work)-1]
+		work = work[:len(work)-1]
+
+		state := newBankState(path.bank)
+		address := path.address
+		for address < 0x8000 {
+			if address >= 0x4000 && state.bank == unknownValue {
+				break
+			}
+			offset := romOffset(state.bank, address)
+			bankEnd := min((offset/romBankSize+1)*romBankSize, len(data))
+			if offset >= bankEnd || covered[offset] {
+				break
+			}
+
+			i := decodeInstruction(data[:bankEnd], offset, address, list)
+			if i.Data || overlaps(covered, offset, len(i.Bytes)) {
+				break
+			}
+			state.update(&i, switching)
+			i.Bank = romLocation(offset).Bank
+			i.TargetBank = UnknownBank
+			if state.bank != unknownValue {
+				i.TargetBank = uint16(state.bank)
+			}
+			decoded[offset] = i
+			for k := range i.Bytes {
+				covered[offset+k] = true
+			}
+
+			if target, ok := i.Target(); ok {
+				work = append(work, disassemblyPath{target, state.bank})
+			}
+			if !i.FallsThrough() {
+				break
+			}
+			address = i.AddressEnd
+		}
+	}
+
+	var instructions []Instruction
+	for offset := 0; offset < len(data); {
+		if i, ok := decoded[offset]; ok {
+			instructions = append(instructions, i)
+			offset += len(i.Bytes)
+			continue
+		}
+
+		// collect the unreached bytes up to the next instruction or the end of the bank
+		bankEnd := min((offset/romBankSize+1)*romBankSize, len(data))
+		end := offset + 1
+		for end < bankEnd && !covered[end] {
+			end++
+		}
+		location := romLocation(offset)
+		instructions = append(instructions, Instruction{
+			Bank:         location.Bank,
+			AddressStart: location.Address,
+			AddressEnd:   location.Address + uint16(end-offset),
+			Bytes:        data[offset:end],
+			Data:         true,
+		})
+		offset = end
+	}
+	return instructions
+}
@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDisassembleRecursive(t *testing.T) {
+	opcodes, err := ParseOpcodes()
+	assert.NoError(t, err)
+
+	data := make([]byte, 0x0200)
+	for i := range data {
+		data[i] = 0xFF
+	}
+	copy(data[0x0100:], []byte{0x00, 0xC3, 0x50, 0x01}) // NOP; JP 0x0150
+	copy(data[0x0150:], []byte{
+		0xCD, 0x60, 0x01, // CALL 0x0160
+		0x20, 0xFB, // JR NZ, 0x0150
+		0x18, 0xFE, // JR 0x0155
+		0x12, 0x34, // data
+	})
+	copy(data[0x0160:], []byte{0x3E, 0x01, 0xC9}) // LD A, 0x01; RET
+
+	instructions := DisassembleRecursive(data, []uint16{entryPointAddress}, opcodes)
+
+	var lines []string
+	end := uint16(0)
+	for _, i := range instructions {
+		assert.Equal(t, end, i.AddressStart)
+		end = i.AddressEnd
+		if !i.Data {
+			lines = append(lines, FormatInstruction(i))
+		}
+	}
+	assert.Equal(t, uint16(len(data)), end)
+	assert.Equal(t, []string{"NOP", "JP 0x0150", "CALL 0x0160", "JR NZ, -5 (0xFB)", "JR -2 (0xFE)", "LD A, 0x01", "RET"}, lines)
+
+	// the bytes after the endless loop are data
+	for _, i := range instructions {
+		if i.AddressStart == 0x0157 {
+			assert.True(t, i.Data)
+			assert.Equal(t, []byte{0x12, 0x34}, i.Bytes[:2])
+		}
+	}
+}
+
+func TestEntryPoints(t *testing.T) {
+	entryPoints := EntryPoints()
+	assert.Len(t, entryPoints, 14)
+	assert.Contains(t, entryPoints, uint16(0x0038))
+	assert.Contains(t, entryPoints, uint16(0x0060))
+}
@@ -19,6 +19,17 @@ func TestDisassembler(t *testing.T) {
 		{[]byte{0x3E, 0x01}, "LD A, 0x01"},
 		{[]byte{0xEA, 0x1C, 0xC3}, "LD [0xC31C], A"},
 		{[]byte{0xF1}, "POP AF"},
+		{[]byte{0x00}, "NOP"},
+		{[]byte{0xCB, 0x37}, "SWAP A"},
+		{[]byte{0xCB, 0x7C}, "BIT 7, H"},
+		{[]byte{0xCB, 0x86}, "RES 0, [HL]"},
+		{[]byte{0xD3}, "db 0xD3"},
+		{[]byte{0xCB}, "db 0xCB"},
+		{[]byte{0xCD, 0xA3}, "db 0xCD, 0xA3"},
+		{[]byte{0x22}, "LD [HL+], A"},
+		{[]byte{0xF8, 0xFD}, "LD HL, SP-3"},
+		{[]byte{0xE0, 0x44}, "LDH [0x44], A"},
+		{[]byte{0xDF}, "RST 0x18(H)"},
 	}
 
 	opcodes, err := ParseOpcodes()
@@ -27,10 +38,65 @@ func TestDisassembler(t *testing.T) {
 	for _, d := range data {
 		instructions := Disassemble(d.code, 0, opcodes)
 		assert.Equal(t, 1, len(instructions))
-		assert.Equal(t, d.instruction, instructions[0].Line)
+		assert.Equal(t, d.instruction, FormatInstruction(instructions[0]))
 	}
 }
 
+func TestDisassembleIllegalAndTruncated(t *testing.T) {
+	opcodes, err := ParseOpcodes()
+	assert.NoError(t, err)
+
+	instructions := Disassemble([]byte{0xDB, 0xCB, 0x11, 0x3E}, 0, opcodes)
+	assert.Len(t, instructions, 3)
+	assert.True(t, instructions[0].Data)
+	assert.Equal(t, FlowIllegal, instructions[0].Flow)
+	assert.Equal(t, "RL C", FormatInstruction(instructions[1]))
+	assert.True(t, instructions[1].Prefixed)
+	assert.Equal(t, []byte{0xCB, 0x11}, instructions[1].Bytes)
+	assert.Equal(t, uint16(3), instructions[1].AddressEnd)
+	assert.True(t, instructions[2].Data)
+	assert.Equal(t, "db 0x3E", FormatInstruction(instructions[2]))
+}
+
+func TestInstructionControlFlow(t *testing.T) {
+	opcodes, err := ParseOpcodes()
+	assert.NoError(t, err)
+
+	data := []struct {
+		code         []byte
+		flow         ControlFlow
+		conditional  bool
+		target       uint16
+		hasTarget    bool
+		fallsThrough bool
+	}{
+		{[]byte{0x00}, FlowNone, false, 0, false, true},
+		{[]byte{0x18, 0xFE}, FlowJump, false, 0x0100, true, false},
+		{[]byte{0x20, 0x05}, FlowJump, true, 0x0107, true, true},
+		{[]byte{0xDA, 0x34, 0x12}, FlowJump, true, 0x1234, true, true},
+		{[]byte{0xE9}, FlowJump, false, 0, false, false},
+		{[]byte{0xCD, 0xA3, 0x17}, FlowCall, false, 0x17A3, true, true},
+		{[]byte{0xFF}, FlowCall, false, 0x0038, true, true},
+		{[]byte{0xD8}, FlowReturn, true, 0, false, true},
+		{[]byte{0xD9}, FlowReturn, false, 0, false, false},
+		{[]byte{0x76}, FlowStop, false, 0, false, true},
+	}
+
+	for _, d := range data {
+		i := decodeInstruction(d.code, 0, 0x0100, opcodes)
+		assert.Equal(t, d.flow, i.Flow, FormatInstruction(i))
+		assert.Equal(t, d.conditional, i.Conditional, FormatInstruction(i))
+		target, ok := i.Target()
+		assert.Equal(t, d.hasTarget, ok, FormatInstruction(i))
+		assert.Equal(t, d.target, target, FormatInstruction(i))
+		assert.Equal(t, d.fallsThrough, i.FallsThrough(), FormatInstruction(i))
+	}
+
+	i := decodeInstruction([]byte{0xE0, 0x44}, 0, 0, opcodes)
+	assert.Equal(t, OperandValue{Kind: OperandAddress, Name: "a8", Value: 0xFF44, Indirect: true}, i.Operands[0])
+	assert.Equal(t, OperandValue{Kind: OperandRegister, Name: "A"}, i.Operands[1])
+}
+
 func TestDisassembleSnake(t *testing.T) {
 	snakeUrl := "https://hh3.gbdev.io/static/database-gb/entries/snake-gb/snake.gb"
 	resp, err := http.Get(snakeUrl)
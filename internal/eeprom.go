@@ -0,0 +1,187 @@
+package internal
+
+// eeprom93LC56 opcodes, following the start bit.
+const (
+	eepromExtended = 0b00 // the upper two address bits select EWEN, EWDS, ERAL or WRAL
+	eepromWrite    = 0b01
+	eepromRead     = 0b10
+	eepromErase    = 0b11
+)
+
+// eepromCommandBits is the length of a command: start bit, 2-bit opcode and 8-bit address.
+const eepromCommandBits = 11
+
+type eepromState uint8
+
+const (
+	eepromIdle eepromState = iota
+	eepromCommand
+	eepromReading
+	eepromWriting
+)
+
+// eeprom93LC56 is the 256 byte serial EEPROM (128 words of 16 bits) of MBC7 cartridges. The game bit-bangs the
+// chip select (CS), clock (CLK) and data in (DI) lines, data is shifted in on the rising edge of the clock.
+type eeprom93LC56 struct {
+	data [256]byte // data holds the words big-endian
+
+	cs, clk, di, do bool
+	writeEnabled    bool
+
+	state   eepromState
+	shift   uint32 // shift collects the bits of the current command or data word
+	bits    int
+	opcode  uint32
+	address uint32
+}
+
+func newEEPROM93LC56() *eeprom93LC56 {
+	e := &eeprom93LC56{do: true}
+	for i := range e.data {
+		e.data[i] = 0xFF
+	}
+	return e
+}
+
+func (e *eeprom93LC56) word(address uint32) uint16 {
+	i := (address & 0x7F) * 2
+	return uint16(e.data[i])<<8 | uint16(e.data[i+1])
+}
+
+func (e *eeprom93LC56) setWord(address uint32, value uint16) {
+	i := (address & 0x7F) * 2
+	e.data[i] = uint8(value >> 8)
+	e.data[i+1] = uint8(value)
+}
+
+// read returns the pins: DO (bit 0), DI (bit 1), CLK (bit 6) and CS (bit 7).
+func (e *eeprom93LC56) read() uint8 {
+	var value uint8
+	if e.do {
+		value |= 0b0000_0001
+	}
+	if e.di {
+		value |= 0b0000_0010
+	}
+	if e.clk {
+		value |= 0b0100_0000
+	}
+	if e.cs {
+		value |= 0b1000_0000
+	}
+	return value
+}
+
+func (e *eeprom93LC56) write(value uint8) {
+	cs := value&0b1000_0000 != 0
+	clk := value&0b0100_0000 != 0
+	e.di = value&0b0000_0010 != 0
+
+	if !cs {
+		// deselecting the chip aborts the current command
+		e.cs, e.clk = false, clk
+		e.state = eepromIdle
+		return
+	}
+
+	rising := !e.clk && clk
+	e.cs, e.clk = true, clk
+	if rising {
+		e.clockIn(e.di)
+	}
+}
+
+func (e *eeprom93LC56) clockIn(bit bool) {
+	switch e.state {
+	case eepromIdle:
+		// wait for the start bit
+		if bit {
+			e.state = eepromCommand
+			e.shift, e.bits = 1, 1
+		}
+	case eepromCommand:
+		e.shiftIn(bit)
+		if e.bits == eepromCommandBits {
+			e.decode()
+		}
+	case eepromReading:
+		e.do = e.shift&0x8000 != 0
+		e.shift <<= 1
+		e.bits++
+		if e.bits == 16 {
+			// sequential read of the next word
+			e.address++
+			e.shift, e.bits = uint32(e.word(e.address)), 0
+		}
+	case eepromWriting:
+		e.shiftIn(bit)
+		if e.bits == 16 {
+			e.finishWrite(uint16(e.shift))
+		}
+	}
+}
+
+func (e *eeprom93LC56) shiftIn(bit bool) {
+	e.shift <<= 1
+	if bit {
+		e.shift |= 1
+	}
+	e.bits++
+}
+
+func (e *eeprom93LC56) decode() {
+	opcode := (e.shift >> 8) & 0b11
+	address := e.shift & 0xFF
+	e.opcode, e.address = opcode, address
+	e.state = eepromIdle
+
+	switch opcode {
+	case eepromRead:
+		// a dummy zero is output before the data
+		e.do = false
+		e.state = eepromReading
+		e.shift, e.bits = uint32(e.word(address)), 0
+	case eepromWrite:
+		e.state = eepromWriting
+		e.shift, e.bits = 0, 0
+	case eepromErase:
+		if e.writeEnabled {
+			e.setWord(address, 0xFFFF)
+		}
+		e.do = true
+	case eepromExtended:
+		switch address >> 6 {
+		case 0b00: // EWDS
+			e.writeEnabled = false
+		case 0b01: // WRAL
+			e.state = eepromWriting
+			e.shift, e.bits = 0, 0
+		case 0b10: // ERAL
+			if e.writeEnabled {
+				for i := range e.data {
+					e.data[i] = 0xFF
+				}
+			}
+			e.do = true
+		case 0b11: // EWEN
+			e.writeEnabled = true
+		}
+	}
+}
+
+func (e *eeprom93LC56) finishWrite(value uint16) {
+	e.state = eepromIdle
+	e.do = true
+	if !e.writeEnabled {
+		return
+	}
+
+	if e.opcode == eepromWrite {
+		e.setWord(e.address, value)
+		return
+	}
+	// WRAL
+	for address := uint32(0); address < 128; address++ {
+		e.setWord(address, value)
+	}
+}
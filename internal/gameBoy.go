@@ -1,32 +1,186 @@
 package internal
 
 import (
+	"bytes"
+	"context"
 	"log/slog"
 	"os"
+	"time"
 )
 
+// clockSpeed is the number of T-cycles per second in normal speed mode.
+const clockSpeed = 4194304
+
 type GameBoy struct {
-	cpu    cpu
-	memory memory
+	cpu   cpu
+	bus   Bus
+	timer timer
+	ppu   ppu
+	apu   apu
+
+	interrupts interrupts
+	model      HardwareModel
+	speed      speed
+	rumble     bool
+	onRumble   []func(on bool)
+	savePath   string // savePath is the .sav file of battery-backed cartridges, empty otherwise
+	savedRAM   []byte // savedRAM is the RAM as of the last flush
+	cycles     uint64 // cycles is the master clock, the number of T-cycles since power on in normal speed
 }
 
-func (gb *GameBoy) LoadCartridge(path string) error {
+func (gb *GameBoy) LoadCartridge(path string, options ...CartridgeOption) error {
 	rom, err := os.ReadFile(path)
 	if err != nil {
 		slog.Error("Error in reading rom", "error", err)
 		return err
 	}
 
-	copy(gb.memory.data[0:], rom)
+	if err := checkHeader(rom, newCartridgeOptions(options).strictHeader); err != nil {
+		slog.Error("Error in cartridge header", "error", err)
+		return err
+	}
+
+	cartridge, err := NewCartridge(rom, options...)
+	if err != nil {
+		slog.Error("Error in loading cartridge", "error", err)
+		return err
+	}
+	gb.bus.cartridge = cartridge
+	if battery, ok := cartridge.(batteryCartridge); ok && hasBattery(rom) {
+		gb.savePath = SavePath(path)
+		if err := loadSave(gb.savePath, battery); err != nil {
+			slog.Error("Error in loading save", "error", err)
+			return err
+		}
+		gb.savedRAM = bytes.Clone(battery.saveRAM())
+	}
+	if rumble, ok := cartridge.(rumbleCartridge); ok {
+		rumble.setRumbleHandler(gb.setRumble)
+	}
+
+	header, _ := NewHeader(rom)
+	gb.model = header.PreferredModel()
+	if gb.model == ModelCGB {
+		gb.bus.speed = &gb.speed
+	}
 
 	return nil
 }
 
+// checkHeader validates the header of the rom. Problems are logged as warnings, or returned as HeaderError if strict.
+func checkHeader(rom []byte, strict bool) error {
+	header, err := NewHeader(rom)
+	if err != nil {
+		return err
+	}
+
+	problems := header.Validate(rom)
+	if len(problems) == 0 {
+		return nil
+	}
+	if strict {
+		return &HeaderError{Problems: problems}
+	}
+	for _, problem := range problems {
+		slog.Warn("Invalid cartridge header", "problem", problem.Error())
+	}
+	return nil
+}
+
 func NewGameBoy() *GameBoy {
-	return &GameBoy{}
+	gb := &GameBoy{}
+	gb.bus.timer = &gb.timer
+	gb.bus.ppu = &gb.ppu
+	gb.bus.interrupts = &gb.interrupts
+	return gb
+}
+
+// Bus returns the memory bus, e.g. to register access hooks.
+func (gb *GameBoy) Bus() *Bus {
+	return &gb.bus
+}
+
+// OnRumble registers a function that is called whenever the rumble motor of the cartridge is switched on or off.
+func (gb *GameBoy) OnRumble(handler func(on bool)) {
+	gb.onRumble = append(gb.onRumble, handler)
+}
+
+// Rumble reports whether the rumble motor of the cartridge is on.
+func (gb *GameBoy) Rumble() bool {
+	return gb.rumble
+}
+
+func (gb *GameBoy) setRumble(on bool) {
+	gb.rumble = on
+	for _, handler := range gb.onRumble {
+		handler(on)
+	}
+}
+
+// SetTilt sets the acceleration in g on the x and y axis for cartridges with an accelerometer (MBC7). Other
+// cartridges ignore it.
+func (gb *GameBoy) SetTilt(x, y float64) {
+	if tilt, ok := gb.bus.cartridge.(tiltCartridge); ok {
+		tilt.setTilt(x, y)
+	}
 }
 
-func (gb *GameBoy) Run() {
+// SetSymbols names the instructions in the trace with the symbols, e.g. loaded from the .sym file of the rom.
+func (gb *GameBoy) SetSymbols(symbols *Symbols) {
+	gb.cpu.symbols = symbols
+}
+
+// Model returns the hardware model emulated for the loaded cartridge.
+func (gb *GameBoy) Model() HardwareModel {
+	return gb.model
+}
+
+// Cycles returns the number of T-cycles since power on.
+func (gb *GameBoy) Cycles() uint64 {
+	return gb.cycles
+}
+
+// tick advances the master clock and all components running in lockstep with the cpu by the given number of cpu
+// T-cycles. In double speed mode the ppu and apu only advance by half of the cpu cycles. While the cpu is stopped, the
+// components do not run.
+func (gb *GameBoy) tick(cycles int) {
+	normalSpeedCycles := cycles
+	if gb.speed.double {
+		normalSpeedCycles /= 2
+	}
+	gb.cycles += uint64(normalSpeedCycles)
+	if gb.cpu.stopped {
+		return
+	}
+
+	gb.timer.tick(cycles)
+	gb.ppu.tick(normalSpeedCycles)
+	gb.apu.tick(normalSpeedCycles)
+	if clock, ok := gb.bus.cartridge.(cartridgeClock); ok {
+		clock.tick(normalSpeedCycles)
+	}
+
+	if gb.timer.overflow {
+		gb.timer.overflow = false
+		gb.interrupts.request(timerInterrupt)
+	}
+	if gb.ppu.vBlank {
+		gb.ppu.vBlank = false
+		gb.interrupts.request(vBlankInterrupt)
+	}
+}
+
+// step runs a single instruction or interrupt dispatch and advances the other components by the time it took. It
+// returns the number of T-cycles.
+func (gb *GameBoy) step() int {
+	cycles := gb.cpu.step(&gb.bus)
+	gb.tick(cycles)
+	return cycles
+}
+
+// Run emulates the Game Boy in real time until the context is done. The save RAM of battery-backed cartridges is
+// flushed periodically and before returning.
+func (gb *GameBoy) Run(ctx context.Context) error {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 
 	const headerEntryAddress uint16 = 0x0100
@@ -34,7 +188,24 @@ func (gb *GameBoy) Run() {
 	gb.cpu.registers.pc = headerEntryAddress
 	gb.cpu.registers.sp = initialStackPointerAddress
 
-	for {
-		gb.cpu.runInstruction(&gb.memory)
+	// pace the emulation to real time frame by frame, so idle loops sleep instead of using the host cpu
+	const frameDuration = time.Second * cyclesPerFrame / clockSpeed
+	next := time.Now()
+	for frame := 1; ctx.Err() == nil; frame++ {
+		frameEnd := gb.cycles + cyclesPerFrame
+		for gb.cycles < frameEnd {
+			gb.step()
+		}
+
+		if frame%saveFlushInterval == 0 {
+			if err := gb.FlushSave(false); err != nil {
+				slog.Error("Error in writing save", "error", err)
+			}
+		}
+
+		next = next.Add(frameDuration)
+		time.Sleep(time.Until(next))
 	}
+
+	return gb.FlushSave(true)
 }
@@ -54,6 +54,14 @@ type Header struct {
 	}
 }
 
+// nintendoLogo is the bitmap at 0x0104-0x0133 the boot ROM compares before starting the game.
+// https://gbdev.io/pandocs/The_Cartridge_Header.html#0104-0133--nintendo-logo
+var nintendoLogo = [48]byte{
+	0xCE, 0xED, 0x66, 0x66, 0xCC, 0x0D, 0x00, 0x0B, 0x03, 0x73, 0x00, 0x83, 0x00, 0x0C, 0x00, 0x0D,
+	0x00, 0x08, 0x11, 0x1F, 0x88, 0x89, 0x00, 0x0E, 0xDC, 0xCC, 0x6E, 0xE6, 0xDD, 0xDD, 0xD9, 0x99,
+	0xBB, 0xBB, 0x67, 0x63, 0x6E, 0x0E, 0xEC, 0xCC, 0xDD, 0xDC, 0x99, 0x9F, 0xBB, 0xB9, 0x33, 0x3E,
+}
+
 func NewHeader(b []byte) (Header, error) {
 	header := Header{}
 	buf := bytes.NewReader(b)
@@ -61,40 +69,41 @@ func NewHeader(b []byte) (Header, error) {
 	return header, err
 }
 
-func (h *Header) CartridgeType() string {
-	// https://gbdev.io/pandocs/The_Cartridge_Header.html#0147--cartridge-type
-	cartridgeTypeMap := map[byte]string{
-		0x00: "ROM ONLY",
-		0x01: "MBC1",
-		0x02: "MBC1+RAM",
-		0x03: "MBC1+RAM+BATTERY",
-		0x05: "MBC2",
-		0x06: "MBC2+BATTERY",
-		0x08: "ROM+RAM 9",
-		0x09: "ROM+RAM+BATTERY 9",
-		0x0B: "MMM01",
-		0x0C: "MMM01+RAM",
-		0x0D: "MMM01+RAM+BATTERY",
-		0x0F: "MBC3+TIMER+BATTERY",
-		0x10: "MBC3+TIMER+RAM+BATTERY 10",
-		0x11: "MBC3",
-		0x12: "MBC3+RAM 10",
-		0x13: "MBC3+RAM+BATTERY 10",
-		0x19: "MBC5",
-		0x1A: "MBC5+RAM",
-		0x1B: "MBC5+RAM+BATTERY",
-		0x1C: "MBC5+RUMBLE",
-		0x1D: "MBC5+RUMBLE+RAM",
-		0x1E: "MBC5+RUMBLE+RAM+BATTERY",
-		0x20: "MBC6",
-		0x22: "MBC7+SENSOR+RUMBLE+RAM+BATTERY",
-		0xFC: "POCKET CAMERA",
-		0xFD: "BANDAI TAMA5",
-		0xFE: "HuC3",
-		0xFF: "HuC1+RAM+BATTERY",
-	}
+// cartridgeTypeNames maps the cartridge type of the header to its name.
+// https://gbdev.io/pandocs/The_Cartridge_Header.html#0147--cartridge-type
+var cartridgeTypeNames = map[byte]string{
+	0x00: "ROM ONLY",
+	0x01: "MBC1",
+	0x02: "MBC1+RAM",
+	0x03: "MBC1+RAM+BATTERY",
+	0x05: "MBC2",
+	0x06: "MBC2+BATTERY",
+	0x08: "ROM+RAM 9",
+	0x09: "ROM+RAM+BATTERY 9",
+	0x0B: "MMM01",
+	0x0C: "MMM01+RAM",
+	0x0D: "MMM01+RAM+BATTERY",
+	0x0F: "MBC3+TIMER+BATTERY",
+	0x10: "MBC3+TIMER+RAM+BATTERY 10",
+	0x11: "MBC3",
+	0x12: "MBC3+RAM 10",
+	0x13: "MBC3+RAM+BATTERY 10",
+	0x19: "MBC5",
+	0x1A: "MBC5+RAM",
+	0x1B: "MBC5+RAM+BATTERY",
+	0x1C: "MBC5+RUMBLE",
+	0x1D: "MBC5+RUMBLE+RAM",
+	0x1E: "MBC5+RUMBLE+RAM+BATTERY",
+	0x20: "MBC6",
+	0x22: "MBC7+SENSOR+RUMBLE+RAM+BATTERY",
+	0xFC: "POCKET CAMERA",
+	0xFD: "BANDAI TAMA5",
+	0xFE: "HuC3",
+	0xFF: "HuC1+RAM+BATTERY",
+}
 
-	return cartridgeTypeMap[h.Raw.CartridgeType]
+func (h *Header) CartridgeType() string {
+	return cartridgeTypeNames[h.Raw.CartridgeType]
 }
 
 type RomSizeInfo struct {
@@ -121,3 +130,162 @@ func (h *Header) RomSize() RomSizeInfo {
 	}
 	return m[h.Raw.RomSize]
 }
+
+// CGBSupport tells whether a game uses the Game Boy Color features.
+type CGBSupport int
+
+const (
+	CGBNone       CGBSupport = iota // monochrome game
+	CGBCompatible                   // supports CGB features and works on older models (0x80)
+	CGBOnly                         // works on CGB only (0xC0)
+)
+
+func (s CGBSupport) String() string {
+	switch s {
+	case CGBCompatible:
+		return "CGB compatible"
+	case CGBOnly:
+		return "CGB only"
+	default:
+		return "DMG"
+	}
+}
+
+// HardwareModel is the Game Boy model emulated for a game.
+type HardwareModel int
+
+const (
+	ModelDMG HardwareModel = iota
+	ModelSGB
+	ModelCGB
+)
+
+func (m HardwareModel) String() string {
+	switch m {
+	case ModelSGB:
+		return "SGB"
+	case ModelCGB:
+		return "CGB"
+	default:
+		return "DMG"
+	}
+}
+
+// cgbFlag returns the last byte of the title area, which is the CGB flag in newer headers.
+func (h *Header) cgbFlag() byte {
+	return h.Raw.TitleManufacturerCodeCGBFlag[15]
+}
+
+// CGBSupport decodes the CGB flag (0x0143).
+// https://gbdev.io/pandocs/The_Cartridge_Header.html#0143--cgb-flag
+func (h *Header) CGBSupport() CGBSupport {
+	flag := h.cgbFlag()
+	switch {
+	case flag&0xC0 == 0xC0:
+		return CGBOnly
+	case flag&0x80 != 0:
+		return CGBCompatible
+	default:
+		return CGBNone
+	}
+}
+
+// SGBSupport reports whether the game supports the Super Game Boy functions. The SGB flag (0x0146) is ignored unless
+// the old licensee code is 0x33.
+// https://gbdev.io/pandocs/The_Cartridge_Header.html#0146--sgb-flag
+func (h *Header) SGBSupport() bool {
+	return h.Raw.SGBFlag == 0x03 && h.Raw.OldLicenseeCode == newLicenseeCodeIndicator
+}
+
+// PreferredModel returns the most capable hardware model the game supports.
+func (h *Header) PreferredModel() HardwareModel {
+	switch {
+	case h.CGBSupport() != CGBNone:
+		return ModelCGB
+	case h.SGBSupport():
+		return ModelSGB
+	default:
+		return ModelDMG
+	}
+}
+
+func isManufacturerCode(code []byte) bool {
+	for _, c := range code {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// ManufacturerCode returns the 4-character manufacturer code (0x013F-0x0142) of newer headers, or an empty string.
+// As the code shares its bytes with the title of older headers, it is only detected for CGB games consisting of
+// uppercase letters and digits.
+// https://gbdev.io/pandocs/The_Cartridge_Header.html#013f-0142--manufacturer-code
+func (h *Header) ManufacturerCode() string {
+	code := h.Raw.TitleManufacturerCodeCGBFlag[11:15]
+	if h.CGBSupport() == CGBNone || !isManufacturerCode(code) {
+		return ""
+	}
+	return string(code)
+}
+
+// Title returns the title in upper case ASCII (0x0134-0x0143). Older headers use all 16 bytes, newer headers 15 bytes
+// next to the CGB flag or 11 bytes next to the manufacturer code.
+// https://gbdev.io/pandocs/The_Cartridge_Header.html#0134-0143--title
+func (h *Header) Title() string {
+	title := h.Raw.TitleManufacturerCodeCGBFlag[:]
+	switch {
+	case h.ManufacturerCode() != "":
+		title = title[:11]
+	case h.CGBSupport() != CGBNone:
+		title = title[:15]
+	}
+	if i := bytes.IndexByte(title, 0); i >= 0 {
+		title = title[:i]
+	}
+	return string(title)
+}
+
+// Licensee returns the publisher of the game, from the new licensee code (0x0144-0x0145) if the old licensee code
+// (0x014B) is 0x33, otherwise from the old one. Unknown codes return an empty string.
+func (h *Header) Licensee() string {
+	if h.Raw.OldLicenseeCode == newLicenseeCodeIndicator {
+		return newLicensees[string(h.Raw.NewLicenseeCode[:])]
+	}
+	return oldLicensees[h.Raw.OldLicenseeCode]
+}
+
+type RamSizeInfo struct {
+	RamSize          string
+	Bytes            int
+	NumberOfRamBanks int
+}
+
+func (h *Header) RamSize() RamSizeInfo {
+	// https://gbdev.io/pandocs/The_Cartridge_Header.html#0149--ram-size
+	m := map[byte]string{
+		0x00: "0",
+		0x01: "-",
+		0x02: "8 KiB",
+		0x03: "32 KiB",
+		0x04: "128 KiB",
+		0x05: "64 KiB",
+	}
+	size := ramSize(h.Raw.RamSize)
+	return RamSizeInfo{m[h.Raw.RamSize], size, size / ramBankSize}
+}
+
+// Destination decodes the destination code (0x014A).
+// https://gbdev.io/pandocs/The_Cartridge_Header.html#014a--destination-code
+func (h *Header) Destination() string {
+	if h.Raw.DestinationCode == 0x00 {
+		return "Japan (and possibly overseas)"
+	}
+	return "Overseas only"
+}
+
+// Version returns the mask ROM version number (0x014C).
+func (h *Header) Version() byte {
+	return h.Raw.MaskRomVersionNumber
+}
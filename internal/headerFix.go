@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// FixLogo stores the Nintendo logo in the header.
+func (h *Header) FixLogo() {
+	h.Raw.NintendoLogo = nintendoLogo
+}
+
+// FixHeaderChecksum stores the checksum of the current header fields. Call it after all other changes to the header.
+func (h *Header) FixHeaderChecksum() {
+	h.Raw.HeaderChecksum = h.ComputeHeaderChecksum()
+}
+
+// titleLength returns the number of title bytes that are not shared with the manufacturer code or the CGB flag.
+func (h *Header) titleLength() int {
+	switch {
+	case h.ManufacturerCode() != "":
+		return 11
+	case h.CGBSupport() != CGBNone:
+		return 15
+	default:
+		return 16
+	}
+}
+
+// SetTitle stores the title, padded with zeros. Set the CGB flag first, as it limits the length of the title.
+func (h *Header) SetTitle(title string) error {
+	length := h.titleLength()
+	if len(title) > length {
+		return fmt.Errorf("title %q is longer than %d characters", title, length)
+	}
+	field := h.Raw.TitleManufacturerCodeCGBFlag[:length]
+	clear(field)
+	copy(field, title)
+	return nil
+}
+
+// SetCGBSupport stores the CGB flag (0x0143).
+func (h *Header) SetCGBSupport(support CGBSupport) {
+	flags := map[CGBSupport]byte{CGBNone: 0x00, CGBCompatible: 0x80, CGBOnly: 0xC0}
+	h.Raw.TitleManufacturerCodeCGBFlag[15] = flags[support]
+}
+
+// SetSGBSupport stores the SGB flag (0x0146). The flag only takes effect with the old licensee code 0x33.
+func (h *Header) SetSGBSupport(enabled bool) {
+	h.Raw.SGBFlag = 0x00
+	if enabled {
+		h.Raw.SGBFlag = 0x03
+	}
+}
+
+// SetRomSize stores the ROM size code (0x0148) for a rom of size bytes, which must be a power of two number of banks.
+func (h *Header) SetRomSize(size int) error {
+	banks := size / romBankSize
+	if banks < 2 || size%romBankSize != 0 || bits.OnesCount(uint(banks)) != 1 {
+		return fmt.Errorf("rom size %d is not a power of two number of banks", size)
+	}
+	h.Raw.RomSize = byte(bits.TrailingZeros(uint(banks)) - 1)
+	return nil
+}
+
+// CopyTo stores the header in the first 0x0150 bytes of the rom.
+func (h *Header) CopyTo(rom []byte) {
+	copy(rom, h.bytes())
+}
+
+// FixGlobalChecksum stores the global checksum of the rom in its header.
+func FixGlobalChecksum(rom []byte) {
+	binary.BigEndian.PutUint16(rom[globalChecksumAddress:], ComputeGlobalChecksum(rom))
+}
@@ -0,0 +1,32 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHeaderFix(t *testing.T) {
+	rom := PadROM(newTestROM(0x00, 0x00, 3))
+	assert.Len(t, rom, 4*romBankSize)
+
+	header, err := NewHeader(rom)
+	assert.NoError(t, err)
+	assert.NoError(t, header.SetRomSize(len(rom)))
+	assert.Equal(t, byte(0x01), header.Raw.RomSize)
+	assert.Error(t, header.SetRomSize(3*romBankSize))
+
+	header.SetCGBSupport(CGBCompatible)
+	assert.Error(t, header.SetTitle("SIXTEEN CHARSXXX"))
+	assert.NoError(t, header.SetTitle("FIXED"))
+	header.SetSGBSupport(true)
+	header.FixLogo()
+	header.FixHeaderChecksum()
+	header.CopyTo(rom)
+	FixGlobalChecksum(rom)
+
+	header, _ = NewHeader(rom)
+	assert.Empty(t, header.Validate(rom))
+	assert.Equal(t, "FIXED", header.Title())
+	assert.Equal(t, CGBCompatible, header.CGBSupport())
+	assert.Equal(t, byte(0x03), header.Raw.SGBFlag)
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+const (
+	headerChecksumStart   = 0x0134
+	headerChecksumEnd     = 0x014C // headerChecksumEnd is the last byte included in the header checksum
+	globalChecksumAddress = 0x014E
+)
+
+// HeaderProblemKind identifies the check of the header that failed.
+type HeaderProblemKind int
+
+const (
+	ProblemNintendoLogo HeaderProblemKind = iota
+	ProblemHeaderChecksum
+	ProblemGlobalChecksum
+)
+
+func (k HeaderProblemKind) String() string {
+	switch k {
+	case ProblemNintendoLogo:
+		return "Nintendo logo"
+	case ProblemHeaderChecksum:
+		return "header checksum"
+	default:
+		return "global checksum"
+	}
+}
+
+// HeaderProblem is a failed check of the header. Expected and Actual hold the checksums, they are unused for the logo.
+type HeaderProblem struct {
+	Kind     HeaderProblemKind
+	Expected uint16
+	Actual   uint16
+}
+
+func (p HeaderProblem) Error() string {
+	if p.Kind == ProblemNintendoLogo {
+		return "Nintendo logo does not match"
+	}
+	return fmt.Sprintf("%s mismatch: header contains 0x%02X, computed 0x%02X", p.Kind, p.Actual, p.Expected)
+}
+
+// HeaderError is returned for a rom with header problems when the header is checked strictly.
+type HeaderError struct {
+	Problems []HeaderProblem
+}
+
+func (e *HeaderError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		messages[i] = problem.Error()
+	}
+	return "invalid cartridge header: " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the problems, so errors.As can find a HeaderProblem.
+func (e *HeaderError) Unwrap() []error {
+	errs := make([]error, len(e.Problems))
+	for i, problem := range e.Problems {
+		errs[i] = problem
+	}
+	return errs
+}
+
+// bytes returns the header as it is stored in the rom (0x0000-0x014F).
+func (h *Header) bytes() []byte {
+	var buf bytes.Buffer
+	_ = binary.Write(&buf, binary.BigEndian, &h.Raw)
+	return buf.Bytes()
+}
+
+// ValidLogo reports whether the header contains the Nintendo logo the boot ROM checks.
+func (h *Header) ValidLogo() bool {
+	return h.Raw.NintendoLogo == nintendoLogo
+}
+
+// ComputeHeaderChecksum computes the checksum over 0x0134-0x014C the boot ROM verifies.
+// https://gbdev.io/pandocs/The_Cartridge_Header.html#014d--header-checksum
+func (h *Header) ComputeHeaderChecksum() byte {
+	return headerChecksum(h.bytes())
+}
+
+func headerChecksum(rom []byte) byte {
+	var checksum byte
+	for _, b := range rom[headerChecksumStart : headerChecksumEnd+1] {
+		checksum = checksum - b - 1
+	}
+	return checksum
+}
+
+// GlobalChecksum returns the global checksum stored in the header.
+func (h *Header) GlobalChecksum() uint16 {
+	return binary.BigEndian.Uint16(h.Raw.GlobalChecksum[:])
+}
+
+// ComputeGlobalChecksum computes the sum of all bytes of the rom except the global checksum itself. It is not checked
+// by the hardware.
+// https://gbdev.io/pandocs/The_Cartridge_Header.html#014e-014f--global-checksum
+func ComputeGlobalChecksum(rom []byte) uint16 {
+	var checksum uint16
+	for i, b := range rom {
+		if i == globalChecksumAddress || i == globalChecksumAddress+1 {
+			continue
+		}
+		checksum += uint16(b)
+	}
+	return checksum
+}
+
+// Validate checks the logo, the header checksum and the global checksum of the rom the header was read from.
+func (h *Header) Validate(rom []byte) []HeaderProblem {
+	var problems []HeaderProblem
+	if !h.ValidLogo() {
+		problems = append(problems, HeaderProblem{Kind: ProblemNintendoLogo})
+	}
+	if checksum := h.ComputeHeaderChecksum(); checksum != h.Raw.HeaderChecksum {
+		problems = append(problems, HeaderProblem{ProblemHeaderChecksum, uint16(checksum), uint16(h.Raw.HeaderChecksum)})
+	}
+	if checksum := ComputeGlobalChecksum(rom); checksum != h.GlobalChecksum() {
+		problems = append(problems, HeaderProblem{ProblemGlobalChecksum, checksum, h.GlobalChecksum()})
+	}
+	return problems
+}
@@ -125,3 +125,85 @@ func TestHeaderParsing(t *testing.T) {
 	assert.Equal(t, byte(0x00), header.Raw.RomSize)
 	assert.Equal(t, 2, header.RomSize().NumberOfRomBanks)
 }
+
+func TestHeaderChecksum(t *testing.T) {
+	header := ParseSnakeHeader(t)
+	assert.True(t, header.ValidLogo())
+	assert.Equal(t, byte(0x95), header.ComputeHeaderChecksum())
+	assert.Equal(t, uint16(0xD41B), header.GlobalChecksum())
+}
+
+func TestGlobalChecksum(t *testing.T) {
+	rom := []byte{0x01, 0x02, 0xFF}
+	assert.Equal(t, uint16(0x0102), ComputeGlobalChecksum(rom))
+
+	// the checksum bytes are excluded
+	rom = make([]byte, 0x8000)
+	rom[0x014E], rom[0x014F] = 0x12, 0x34
+	rom[0x7FFF] = 0x01
+	assert.Equal(t, uint16(0x0001), ComputeGlobalChecksum(rom))
+}
+
+func TestHeaderValidate(t *testing.T) {
+	rom := newTestROM(0x00, 0x00, 2)
+	header, err := NewHeader(rom)
+	assert.NoError(t, err)
+
+	problems := header.Validate(rom)
+	assert.Len(t, problems, 3)
+	assert.Equal(t, ProblemNintendoLogo, problems[0].Kind)
+	assert.Equal(t, HeaderProblem{ProblemHeaderChecksum, uint16(header.ComputeHeaderChecksum()), 0}, problems[1])
+	assert.Equal(t, ProblemGlobalChecksum, problems[2].Kind)
+
+	copy(rom[0x0104:], nintendoLogo[:])
+	rom[0x014D] = headerChecksum(rom)
+	checksum := ComputeGlobalChecksum(rom)
+	rom[0x014E], rom[0x014F] = uint8(checksum>>8), uint8(checksum)
+	header, _ = NewHeader(rom)
+	assert.Empty(t, header.Validate(rom))
+}
+
+func TestLoadCartridgeStrictHeader(t *testing.T) {
+	path := writeTestROM(t, newTestROM(0x00, 0x00, 2))
+
+	assert.NoError(t, NewGameBoy().LoadCartridge(path))
+
+	err := NewGameBoy().LoadCartridge(path, WithStrictHeader())
+	var headerError *HeaderError
+	assert.ErrorAs(t, err, &headerError)
+	assert.Len(t, headerError.Problems, 3)
+	var problem HeaderProblem
+	assert.ErrorAs(t, err, &problem)
+	assert.Equal(t, ProblemNintendoLogo, problem.Kind)
+}
+
+func TestHeaderDecoding(t *testing.T) {
+	header := ParseSnakeHeader(t)
+	assert.Equal(t, "SNAKE", header.Title())
+	assert.Equal(t, "", header.ManufacturerCode())
+	assert.Equal(t, CGBNone, header.CGBSupport())
+	assert.False(t, header.SGBSupport())
+	assert.Equal(t, ModelDMG, header.PreferredModel())
+	assert.Equal(t, newLicensees["DH"], header.Licensee())
+	assert.Equal(t, RamSizeInfo{"8 KiB", 0x2000, 1}, header.RamSize())
+	assert.Equal(t, "Overseas only", header.Destination())
+	assert.Equal(t, byte(0x03), header.Version())
+
+	rom := getSnakeHeader()
+	copy(rom[0x0134:], "POKEMON YELAPSE")
+	rom[0x0143] = 0x80
+	rom[0x0146] = 0x03
+	header, _ = NewHeader(rom)
+	assert.Equal(t, "POKEMON YEL", header.Title())
+	assert.Equal(t, "APSE", header.ManufacturerCode())
+	assert.Equal(t, CGBCompatible, header.CGBSupport())
+	assert.True(t, header.SGBSupport())
+	assert.Equal(t, ModelCGB, header.PreferredModel())
+
+	rom[0x0143] = 0xC0
+	rom[0x014B] = 0x01
+	header, _ = NewHeader(rom)
+	assert.Equal(t, CGBOnly, header.CGBSupport())
+	assert.False(t, header.SGBSupport())
+	assert.Equal(t, "Nintendo", header.Licensee())
+}
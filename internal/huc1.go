@@ -0,0 +1,75 @@
+package internal
+
+// huc1IRMode is written to 0x0000-0x1FFF to map the infrared port to 0xA000-0xBFFF instead of RAM.
+const huc1IRMode = 0x0E
+
+// irNoLight is read from the infrared port of HuC1 and HuC3 when no light is received. Infrared communication is not
+// emulated.
+const irNoLight = 0xC0
+
+// huc1 is the HuC1 memory bank controller by Hudson Soft with an infrared port. It is similar to MBC1 without the
+// advanced banking mode.
+// https://gbdev.io/pandocs/HuC1.html
+type huc1 struct {
+	rom []byte
+	ram []byte
+
+	irMode  bool
+	irLED   bool
+	romBank uint8
+	ramBank uint8
+}
+
+func newHuC1(rom, ram []byte) *huc1 {
+	return &huc1{rom: rom, ram: ram, romBank: 1}
+}
+
+func (m *huc1) saveRAM() []byte {
+	return m.ram
+}
+
+func (m *huc1) mappedROMBank() int {
+	return wrapROMBank(m.rom, int(m.romBank))
+}
+
+func (m *huc1) ReadROM(address uint16) uint8 {
+	if address < 0x4000 {
+		return romBank(m.rom, 0, address)
+	}
+	return romBank(m.rom, int(m.romBank), address-0x4000)
+}
+
+func (m *huc1) WriteROM(address uint16, value uint8) {
+	switch {
+	case address < 0x2000:
+		m.irMode = value&0x0F == huc1IRMode
+	case address < 0x4000:
+		m.romBank = value & 0x3F
+		if m.romBank == 0 {
+			m.romBank = 1
+		}
+	case address < 0x6000:
+		m.ramBank = value & 0b11
+	}
+}
+
+func (m *huc1) ReadRAM(address uint16) uint8 {
+	if m.irMode {
+		return irNoLight
+	}
+	if len(m.ram) == 0 {
+		return openBus
+	}
+	return m.ram[ramIndex(m.ram, int(m.ramBank), address-0xA000)]
+}
+
+func (m *huc1) WriteRAM(address uint16, value uint8) {
+	if m.irMode {
+		m.irLED = value&0b1 != 0
+		return
+	}
+	if len(m.ram) == 0 {
+		return
+	}
+	m.ram[ramIndex(m.ram, int(m.ramBank), address-0xA000)] = value
+}
@@ -0,0 +1,25 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHuC1(t *testing.T) {
+	cartridge, err := NewCartridge(newTestROM(0xFF, 0x03, 64))
+	assert.NoError(t, err)
+
+	cartridge.WriteROM(0x2000, 0x3F)
+	assert.Equal(t, uint8(0x3F), cartridge.ReadROM(0x4000))
+
+	cartridge.WriteROM(0x4000, 0x02)
+	cartridge.WriteRAM(0xA000, 0x42)
+	assert.Equal(t, uint8(0x42), cartridge.(*huc1).ram[2*ramBankSize])
+
+	// infrared mode
+	cartridge.WriteROM(0x0000, huc1IRMode)
+	assert.Equal(t, uint8(irNoLight), cartridge.ReadRAM(0xA000))
+	cartridge.WriteRAM(0xA000, 0x01)
+	assert.True(t, cartridge.(*huc1).irLED)
+	assert.Equal(t, uint8(0x42), cartridge.(*huc1).ram[2*ramBankSize])
+}
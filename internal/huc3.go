@@ -0,0 +1,187 @@
+package internal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// HuC3 modes selected by writing to 0x0000-0x1FFF, they define what is mapped to 0xA000-0xBFFF.
+const (
+	huc3ModeRAMReadOnly  = 0x0
+	huc3ModeRAM          = 0xA
+	huc3ModeRTCCommand   = 0xB
+	huc3ModeRTCResponse  = 0xC
+	huc3ModeRTCSemaphore = 0xD
+	huc3ModeIR           = 0xE
+)
+
+// HuC3 RTC commands in the upper nibble of values written in huc3ModeRTCCommand.
+const (
+	huc3CommandRead        = 0x1
+	huc3CommandWrite       = 0x3
+	huc3CommandAddressLow  = 0x4
+	huc3CommandAddressHigh = 0x5
+	huc3CommandExtended    = 0x6
+)
+
+const minutesPerDay = 24 * 60
+
+// huc3 is the HuC3 memory bank controller by Hudson Soft with an infrared port, a real-time clock and a speaker. The
+// RTC is accessed through a command interface with 256 nibbles of memory, the current time is copied to and from the
+// first six nibbles. The infrared port and the speaker are stubs.
+// https://gbdev.io/pandocs/HuC3.html
+type huc3 struct {
+	rom []byte
+	ram []byte
+
+	mode    uint8
+	romBank uint8
+	ramBank uint8
+
+	rtcMemory   [256]uint8
+	rtcAddress  uint8
+	rtcCommand  uint8
+	rtcResponse uint8
+	minutes     uint16 // minutes is the time of day in minutes
+	days        uint16
+	subMinute   int // T-cycles since the last increment of minutes
+	now         func() time.Time
+}
+
+func newHuC3(rom, ram []byte, now func() time.Time) *huc3 {
+	return &huc3{rom: rom, ram: ram, romBank: 1, now: now}
+}
+
+// tick advances the real-time clock by the given number of T-cycles in normal speed.
+func (m *huc3) tick(cycles int) {
+	m.subMinute += cycles
+	for m.subMinute >= 60*clockSpeed {
+		m.subMinute -= 60 * clockSpeed
+		m.minutes++
+		if m.minutes == minutesPerDay {
+			m.minutes = 0
+			m.days = (m.days + 1) & 0x0FFF
+		}
+	}
+}
+
+func (m *huc3) saveRAM() []byte {
+	return m.ram
+}
+
+// saveRTC returns the clock in the 48-byte format of MBC3, with the time of day as hours and minutes and the 12-bit
+// day counter in the day registers. The clock isn't latched, so the latched registers repeat the current time.
+func (m *huc3) saveRTC() []byte {
+	registers := [rtcRegisterCount]uint32{0, uint32(m.minutes % 60), uint32(m.minutes / 60), uint32(m.days & 0xFF), uint32(m.days >> 8)}
+	block := make([]byte, rtcSaveSize)
+	for i, value := range registers {
+		binary.LittleEndian.PutUint32(block[4*i:], value)
+		binary.LittleEndian.PutUint32(block[4*(rtcRegisterCount+i):], value)
+	}
+	binary.LittleEndian.PutUint64(block[8*rtcRegisterCount:], uint64(m.now().Unix()))
+	return block
+}
+
+func (m *huc3) loadRTC(block []byte) error {
+	if len(block) != rtcSaveSize && len(block) != rtcLegacySaveSize {
+		return fmt.Errorf("invalid rtc block size: %d bytes", len(block))
+	}
+	register := func(i int) uint16 {
+		return uint16(binary.LittleEndian.Uint32(block[4*i:]))
+	}
+	m.minutes = (register(rtcHours)*60 + register(rtcMinutes)) % minutesPerDay
+	m.days = (register(rtcDaysHigh)<<8 | register(rtcDaysLow)) & 0x0FFF
+	m.subMinute = 0
+	return nil
+}
+
+func (m *huc3) mappedROMBank() int {
+	return wrapROMBank(m.rom, int(m.romBank))
+}
+
+func (m *huc3) ReadROM(address uint16) uint8 {
+	if address < 0x4000 {
+		return romBank(m.rom, 0, address)
+	}
+	return romBank(m.rom, int(m.romBank), address-0x4000)
+}
+
+func (m *huc3) WriteROM(address uint16, value uint8) {
+	switch {
+	case address < 0x2000:
+		m.mode = value & 0x0F
+	case address < 0x4000:
+		m.romBank = value & 0x7F
+	case address < 0x6000:
+		m.ramBank = value & 0b11
+	}
+}
+
+func (m *huc3) ReadRAM(address uint16) uint8 {
+	switch m.mode {
+	case huc3ModeRAMReadOnly, huc3ModeRAM:
+		if len(m.ram) == 0 {
+			return openBus
+		}
+		return m.ram[ramIndex(m.ram, int(m.ramBank), address-0xA000)]
+	case huc3ModeRTCResponse:
+		return 0x80 | m.rtcCommand<<4 | m.rtcResponse
+	case huc3ModeRTCSemaphore:
+		// bit 0 is set when the RTC is ready for the next command
+		return 0xFF
+	case huc3ModeIR:
+		return irNoLight
+	default:
+		return openBus
+	}
+}
+
+func (m *huc3) WriteRAM(address uint16, value uint8) {
+	switch m.mode {
+	case huc3ModeRAM:
+		if len(m.ram) != 0 {
+			m.ram[ramIndex(m.ram, int(m.ramBank), address-0xA000)] = value
+		}
+	case huc3ModeRTCCommand:
+		m.rtcCommand = (value >> 4) & 0b111
+		m.executeRTCCommand(value & 0x0F)
+	}
+}
+
+func (m *huc3) executeRTCCommand(argument uint8) {
+	switch m.rtcCommand {
+	case huc3CommandRead:
+		m.rtcResponse = m.rtcMemory[m.rtcAddress]
+		m.rtcAddress++
+	case huc3CommandWrite:
+		m.rtcMemory[m.rtcAddress] = argument
+		m.rtcAddress++
+	case huc3CommandAddressLow:
+		m.rtcAddress = m.rtcAddress&0xF0 | argument
+	case huc3CommandAddressHigh:
+		m.rtcAddress = m.rtcAddress&0x0F | argument<<4
+	case huc3CommandExtended:
+		switch argument {
+		case 0x0:
+			// copy the time to the memory, minutes and days as 12-bit values with the lowest nibble first
+			for i := 0; i < 3; i++ {
+				m.rtcMemory[i] = uint8(m.minutes>>(4*i)) & 0x0F
+				m.rtcMemory[3+i] = uint8(m.days>>(4*i)) & 0x0F
+			}
+		case 0x1:
+			// copy the memory to the time
+			m.minutes, m.days = 0, 0
+			for i := 0; i < 3; i++ {
+				m.minutes |= uint16(m.rtcMemory[i]) << (4 * i)
+				m.days |= uint16(m.rtcMemory[3+i]) << (4 * i)
+			}
+			m.minutes %= minutesPerDay
+			m.subMinute = 0
+		case 0x2:
+			// status, always ready
+			m.rtcResponse = 0x1
+		}
+		// 0xE plays a tone on the speaker, which is not emulated
+	}
+}
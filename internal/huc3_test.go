@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func huc3Command(cartridge Cartridge, command, argument uint8) uint8 {
+	cartridge.WriteROM(0x0000, huc3ModeRTCCommand)
+	cartridge.WriteRAM(0xA000, command<<4|argument)
+	cartridge.WriteROM(0x0000, huc3ModeRTCResponse)
+	return cartridge.ReadRAM(0xA000) & 0x0F
+}
+
+func TestHuC3Clock(t *testing.T) {
+	cartridge, err := NewCartridge(newTestROM(0xFE, 0x03, 4))
+	assert.NoError(t, err)
+
+	cartridge.(cartridgeClock).tick(61 * 60 * clockSpeed)
+
+	// latch the time and read the minutes (61 = 0x03D)
+	huc3Command(cartridge, huc3CommandExtended, 0x0)
+	huc3Command(cartridge, huc3CommandAddressLow, 0x0)
+	huc3Command(cartridge, huc3CommandAddressHigh, 0x0)
+	assert.Equal(t, uint8(0xD), huc3Command(cartridge, huc3CommandRead, 0))
+	assert.Equal(t, uint8(0x3), huc3Command(cartridge, huc3CommandRead, 0))
+	assert.Equal(t, uint8(0x0), huc3Command(cartridge, huc3CommandRead, 0))
+
+	// write 2 days and set the time
+	huc3Command(cartridge, huc3CommandWrite, 0x2)
+	huc3Command(cartridge, huc3CommandExtended, 0x1)
+	assert.Equal(t, uint16(2), cartridge.(*huc3).days)
+}
+
+func TestHuC3RAM(t *testing.T) {
+	cartridge, _ := NewCartridge(newTestROM(0xFE, 0x03, 4))
+
+	cartridge.WriteROM(0x0000, huc3ModeRAMReadOnly)
+	cartridge.WriteRAM(0xA000, 0x42)
+	assert.Equal(t, uint8(0x00), cartridge.ReadRAM(0xA000))
+
+	cartridge.WriteROM(0x0000, huc3ModeRAM)
+	cartridge.WriteRAM(0xA000, 0x42)
+	assert.Equal(t, uint8(0x42), cartridge.ReadRAM(0xA000))
+}
@@ -2,20 +2,10 @@ package internal
 
 import (
 	"fmt"
-	"github.com/davecgh/go-spew/spew"
+	"log"
 	"log/slog"
 )
 
-func logInstruction(memory *memory, programCounter uint16, instructionLengthInBytes int, instruction, description string) {
-	pcBegin := programCounter - 1
-	pcEnd := pcBegin + uint16(instructionLengthInBytes)
-	slog.Debug("Instruction", "PC", fmtHex16(pcBegin), "mem", fmt.Sprintf("0x% 2X", memory.data[pcBegin:pcEnd]), "instruction", instruction, "description", description)
-}
-
-func nop(programCounter uint16) {
-	slog.Debug("Instruction", "PC", fmtHex16(programCounter-1), "instruction", "NOP", "description", "No operation. This instruction doesn't do anything, but can be used to add a delay of one machine cycle and increment PC by one.")
-}
-
 func unsigned16(leastSignificantByte uint8, mostSignificantByte uint8) uint16 {
 	// littleEndian
 	return uint16(leastSignificantByte) | uint16(mostSignificantByte)<<8
@@ -36,389 +26,555 @@ func fmtHex8(value uint8) string {
 	return fmt.Sprintf("0x%02X", value)
 }
 
-func readUnsigned8(memory *memory, programCounter *uint16) uint8 {
-	v := memory.read(*programCounter)
+func readUnsigned8(bus *Bus, programCounter *uint16) uint8 {
+	v := bus.read(*programCounter)
 	*programCounter++
 	return v
 }
 
-func readUnsigned16(memory *memory, programCounter *uint16) uint16 {
-	nnLSB := readUnsigned8(memory, programCounter)
-	nnMSB := readUnsigned8(memory, programCounter)
+func readUnsigned16(bus *Bus, programCounter *uint16) uint16 {
+	nnLSB := readUnsigned8(bus, programCounter)
+	nnMSB := readUnsigned8(bus, programCounter)
 	nn := unsigned16(nnLSB, nnMSB)
 	return nn
 }
 
-func jp(memory *memory, programCounter *uint16) {
-	pc := *programCounter
-	instructionLengthInBytes := 3
-	a16 := readUnsigned16(memory, programCounter)
-	*programCounter = a16
-	logInstruction(memory, pc, instructionLengthInBytes, fmt.Sprintf("JP 0x%04X", a16), "JP nn: Unconditional jump to the absolute address specified by the 16-bit immediate operand nn.")
+func push(bus *Bus, stackPointer *uint16, address uint16) {
+	*stackPointer--
+	msb, lsb := mostAndLeastSignificantByte(address)
+	bus.write(*stackPointer, msb)
+	*stackPointer--
+	bus.write(*stackPointer, lsb)
+	slog.Debug("Push to stack", "address", fmtHex16(address), "new stack pointer address", fmtHex16(*stackPointer))
 }
 
-func relativeJump(memory *memory, programCounter *uint16) {
-	pc := *programCounter
+func pop(bus *Bus, stackPointer *uint16) uint16 {
+	leastSignificantByte := bus.read(*stackPointer)
+	*stackPointer++
+	mostSignificantByte := bus.read(*stackPointer)
+	*stackPointer++
+	return unsigned16(leastSignificantByte, mostSignificantByte)
+}
 
-	n8 := readUnsigned8(memory, programCounter)
-	e8 := int8(n8)
-	newPc := int32(*programCounter) + int32(e8)
-	if newPc < 0 {
-		panic("negative program counter encountered.")
-	}
-	*programCounter = uint16(newPc)
+func halfCarryAdd(a, b uint8) bool {
+	return (a&0b1111)+(b&0b1111) > 0b1111
+}
 
-	instructionLengthInBytes := 2
-	instruction := fmt.Sprintf("JR %d", e8)
-	description := "JR e: Unconditional jump to the relative address speciﬁed by the signed 8-bit operand e."
-	logInstruction(memory, pc, instructionLengthInBytes, instruction, description)
+func carryAdd(a, b uint8) bool {
+	return uint16(a)+uint16(b) > 0b1111_1111
 }
 
-func relativeJumpConditional(memory *memory, programCounter *uint16, condition bool, conditionName string) {
-	pc := *programCounter
+func halfCarrySub(a, b uint8) bool {
+	return (a & 0b1111) < (b & 0b1111)
+}
 
-	n8 := readUnsigned8(memory, programCounter)
-	e8 := int8(n8)
-	if condition {
-		newPc := int32(*programCounter) + int32(e8)
-		if newPc < 0 {
-			panic("negative program counter encountered.")
-		}
-		*programCounter = uint16(newPc)
-	}
+func carrySub(a, b uint8) bool {
+	return a < b
+}
+
+// The handlers below implement the instructions referenced by the generated opcode tables in opcodeTable.go. Flags
+// are written freely by the handlers; flags the table marks as unaffected ("-") or constant ("0", "1") are fixed up
+// afterward by cpu.execute.
 
-	instructionLengthInBytes := 2
-	instruction := fmt.Sprintf("JR %s, %d", conditionName, e8)
-	description := "JR cc, e: Conditional jump to the relative address speciﬁed by the signed 8-bit operand e, depending on the condition cc."
-	logInstruction(memory, pc, instructionLengthInBytes, instruction, description)
+func (cpu *cpu) nop() {
+	// No operation. This instruction doesn't do anything, but can be used to add a delay of one machine cycle.
 }
 
-func load16BitToRegister(memory *memory, programCounter *uint16, register *uint16, registerName string) {
-	pc := *programCounter
-	instructionLengthInBytes := 3
-	n16 := readUnsigned16(memory, programCounter)
-	*register = n16
-	logInstruction(memory, pc, instructionLengthInBytes, fmt.Sprintf("LD %s, 0x%04X", registerName, n16), "LD rr, nn: Load to the 16-bit register rr, the immediate 16-bit data nn.")
+// illegal handles the opcodes without an instruction. On hardware they lock up the cpu.
+func (cpu *cpu) illegal(opcode uint8) {
+	log.Panicf("illegal opcode: 0x%02X", opcode)
 }
 
-func load8BitToRegister(memory *memory, programCounter *uint16, register *uint8, registerName string) {
-	pc := *programCounter
-	instructionLengthInBytes := 2
-	n8 := readUnsigned8(memory, programCounter)
-	*register = n8
-	instruction := fmt.Sprintf("LD %s, 0x%02X", registerName, n8)
-	description := "LD r, n: Load to the 8-bit register r, the immediate 8-bit data n."
-	logInstruction(memory, pc, instructionLengthInBytes, instruction, description)
+// ld8 loads the 8-bit source into the 8-bit destination (LD r, r' / LD r, n / LD [rr], r / LDH ...).
+func (cpu *cpu) ld8(bus *Bus, destination, source operand8) {
+	cpu.write8(bus, destination, cpu.read8(bus, source))
 }
 
-func load8BitToRegisterFromRegister(memory *memory, programCounter uint16, registerDest *uint8, registerSource uint8, registerDestName, registerSourceName string) {
-	instructionLengthInBytes := 1
-	*registerDest = registerSource
-	instruction := fmt.Sprintf("LD %s, %s", registerDestName, registerSourceName)
-	description := "LD r, r': Load to the 8-bit register r, data from the 8-bit register r'."
-	logInstruction(memory, programCounter, instructionLengthInBytes, instruction, description)
+// ld16 loads the 16-bit source into the 16-bit destination (LD rr, nn / LD [nn], SP / LD SP, HL).
+func (cpu *cpu) ld16(bus *Bus, destination, source operand16) {
+	cpu.write16(bus, destination, cpu.read16(bus, source))
 }
 
-func load8BitToRegisterFromAddressInRegister(memory *memory, programCounter uint16, register *uint8, sourceRegisterValue uint16, registerName, sourceRegisterName string) {
-	instructionLengthInBytes := 1
-	*register = memory.read(sourceRegisterValue)
-	instruction := fmt.Sprintf("LD %s, [%s]", registerName, sourceRegisterName)
-	description := "LD r, [r']: Load to the 8-bit register r, data from the absolute address speciﬁed by the 16-bit register r'."
-	logInstruction(memory, programCounter, instructionLengthInBytes, instruction, description)
+func addSignedToStackPointerImpl(stackPointer uint16, e8 int8, flags flagsPtr) uint16 {
+	// flags are computed from the unsigned addition of the lower byte
+	n8 := uint8(e8)
+	sp := lowPart(stackPointer)
+
+	flags.clear()
+	if halfCarryAdd(sp, n8) {
+		flags.setH()
+	}
+	if carryAdd(sp, n8) {
+		flags.setC()
+	}
+
+	return uint16(int32(stackPointer) + int32(e8))
 }
 
-func load8BitToAddressInHLFromRegister(memory *memory, programCounter uint16, register *uint8, registerHL uint16, registerName string) {
-	instructionLengthInBytes := 1
-	*register = memory.read(registerHL)
-	instruction := fmt.Sprintf("LD [HL], %s", registerName)
-	description := "LD [HL], r: Load to the absolute address speciﬁed by the 16-bit register HL, data from the 8-bit register r."
-	logInstruction(memory, programCounter, instructionLengthInBytes, instruction, description)
+// ldHLStackPointerOffset adds the signed immediate e8 to SP and stores the result in HL (LD HL, SP+e8).
+func (cpu *cpu) ldHLStackPointerOffset(bus *Bus) {
+	e8 := int8(readUnsigned8(bus, &cpu.registers.pc))
+	cpu.registers.hl = addSignedToStackPointerImpl(cpu.registers.sp, e8, cpu.registers.flags())
 }
 
-func load8BitToAddressInHL(memory *memory, programCounter *uint16, registerHL uint16) {
-	pc := *programCounter
-	instructionLengthInBytes := 2
-	n8 := readUnsigned8(memory, programCounter)
-	memory.write(registerHL, n8)
-	instruction := fmt.Sprintf("LD [HL], %02X", n8)
-	description := "LD [HL], n: Load to the absolute address speciﬁed by the 16-bit register HL, the immediate data n."
-	logInstruction(memory, pc, instructionLengthInBytes, instruction, description)
+// addStackPointer adds the signed immediate e8 to SP (ADD SP, e8).
+func (cpu *cpu) addStackPointer(bus *Bus) {
+	e8 := int8(readUnsigned8(bus, &cpu.registers.pc))
+	cpu.registers.sp = addSignedToStackPointerImpl(cpu.registers.sp, e8, cpu.registers.flags())
 }
 
-func loadFromAccumulatorDirect(memory *memory, programCounter *uint16, registerA uint8) {
-	pc := *programCounter
-	a16 := readUnsigned16(memory, programCounter)
-	memory.write(a16, registerA)
+func increment8BitImpl(value uint8, flags flagsPtr) uint8 {
+	result := value + 1
+
+	flags.clear()
+	if result == 0 {
+		flags.setZ()
+	}
+	if halfCarryAdd(value, 1) {
+		flags.setH()
+	}
 
-	instructionLengthInBytes := 3
-	instruction := fmt.Sprintf("LD [%04X], A", a16)
-	description := "LD [a16], A: Load to the absolute address speciﬁed by the 16-bit operand a16, data from the 8-bit A register."
-	logInstruction(memory, pc, instructionLengthInBytes, instruction, description)
+	return result
 }
 
-func loadFromRegisterIndirect(memory *memory, programCounter uint16, registerDest uint16, registerSource uint8, registerDestName, registerSourceName string) {
-	a16 := registerDest
-	memory.write(a16, registerSource)
+func decrement8BitImpl(value uint8, flags flagsPtr) uint8 {
+	result := value - 1
 
-	instructionLengthInBytes := 1
-	instruction := fmt.Sprintf("LD [%s], %s", registerDestName, registerSourceName)
-	description := "LD [r'], r: Load to the absolute address speciﬁed by the 16-bit register r', data from the 8-bit register r."
-	logInstruction(memory, programCounter, instructionLengthInBytes, instruction, description)
+	flags.clear()
+	if result == 0 {
+		flags.setZ()
+	}
+	flags.setN()
+	if halfCarrySub(value, 1) {
+		flags.setH()
+	}
+
+	return result
 }
 
-func loadAccumulatorDirectLeastSignificantByte(memory *memory, programCounter *uint16, registerA *uint8) {
-	pc := *programCounter
-	n8 := readUnsigned8(memory, programCounter)
-	mostSignificantByte := uint8(0xFF)
-	a16 := unsigned16(n8, mostSignificantByte)
-	a := memory.read(a16)
-	*registerA = a
+func (cpu *cpu) inc8(bus *Bus, operand operand8) {
+	cpu.modify8(bus, operand, func(value uint8) uint8 {
+		return increment8BitImpl(value, cpu.registers.flags())
+	})
+}
 
-	instructionLengthInBytes := 2
-	instruction := fmt.Sprintf("LDH A, [0x%02X]", n8)
-	description := "LDH A, [n]: Load to the 8-bit A register, data from the address speciﬁed by the 8-bit immediate data n. The full 16-bit absolute address is obtained by setting the most signiﬁcant byte to 0xFF and the least signiﬁcant byte to the value of n, so the possible range is 0xFF00-0xFFFF."
-	logInstruction(memory, pc, instructionLengthInBytes, instruction, description)
+func (cpu *cpu) dec8(bus *Bus, operand operand8) {
+	cpu.modify8(bus, operand, func(value uint8) uint8 {
+		return decrement8BitImpl(value, cpu.registers.flags())
+	})
 }
 
-func loadAccumulatorIndirectHLIncrement(memory *memory, programCounter uint16, registerA *uint8, registerHL *uint16) {
-	a8 := memory.read(*registerHL)
-	*registerA = a8
-	*registerHL++
+func (cpu *cpu) inc16(operand operand16) {
+	*cpu.registers.register16Ptr(operand)++
+}
 
-	instructionLengthInBytes := 1
-	instruction := "LDI A, [HL]"
-	description := "LDI A, [HL]: Load to the 8-bit A register, data from the absolute address speciﬁed by the 16-bit register HL. The value of HL is incremented after the memory read."
-	logInstruction(memory, programCounter, instructionLengthInBytes, instruction, description)
+func (cpu *cpu) dec16(operand operand16) {
+	*cpu.registers.register16Ptr(operand)--
 }
 
-func loadAccumulatorIndirectHLDecrement(memory *memory, programCounter uint16, registerA *uint8, registerHL *uint16) {
-	a8 := memory.read(*registerHL)
-	*registerA = a8
-	*registerHL--
+// addHL adds the 16-bit register to HL (ADD HL, rr).
+func (cpu *cpu) addHL(operand operand16) {
+	hl := cpu.registers.hl
+	value := *cpu.registers.register16Ptr(operand)
+	cpu.registers.hl = hl + value
 
-	instructionLengthInBytes := 1
-	instruction := "LDD A, [HL]"
-	description := "LDD A, [HL]: Load to the 8-bit A register, data from the absolute address speciﬁed by the 16-bit register HL. The value of HL is incremented after the memory read."
-	logInstruction(memory, programCounter, instructionLengthInBytes, instruction, description)
-}
-
-func call(memory *memory, programCounter *uint16, stackPointer *uint16) {
-	pc := *programCounter
-	instructionLengthInBytes := 3
-	a16 := readUnsigned16(memory, programCounter)
-	addressOfNextInstruction := *programCounter
-	push(memory, stackPointer, addressOfNextInstruction)
-	*programCounter = a16
-	logInstruction(memory, pc, instructionLengthInBytes, fmt.Sprintf("CALL 0x%04X", a16), "CALL nn: Unconditional function call to the absolute address specified by the 16-bit operand nn.")
+	flags := cpu.registers.flags()
+	flags.clear()
+	if (hl&0x0FFF)+(value&0x0FFF) > 0x0FFF {
+		flags.setH()
+	}
+	if uint32(hl)+uint32(value) > 0xFFFF {
+		flags.setC()
+	}
 }
 
-func push(memory *memory, stackPointer *uint16, address uint16) {
-	*stackPointer--
-	msb, lsb := mostAndLeastSignificantByte(address)
-	memory.write(*stackPointer, msb)
-	*stackPointer--
-	memory.write(*stackPointer, lsb)
-	slog.Debug("Push to stack", "address", fmtHex16(address), "new stack pointer address", fmtHex16(*stackPointer))
+func addImpl(registerA *uint8, n8 uint8, flags flagsPtr) {
+	a := *registerA
+	result := a + n8
+	*registerA = result
+
+	flags.clear()
+
+	if result == 0 {
+		flags.setZ()
+	}
+	if halfCarryAdd(a, n8) {
+		flags.setH()
+	}
+	if carryAdd(a, n8) {
+		flags.setC()
+	}
 }
 
-func returnImpl(memory *memory, programCounter *uint16, stackPointer *uint16) {
-	leastSignificantByte := memory.read(*stackPointer)
-	*stackPointer++
-	mostSignificantByte := memory.read(*stackPointer)
-	*stackPointer++
-	a16 := unsigned16(leastSignificantByte, mostSignificantByte)
-	*programCounter = a16
+func addWithCarryImpl(registerA *uint8, n8 uint8, flags flagsPtr) {
+	a := *registerA
+	carry := uint8(0)
+	if flags.c() {
+		carry = 1
+	}
+	result := a + n8 + carry
+	*registerA = result
+
+	flags.clear()
+	if result == 0 {
+		flags.setZ()
+	}
+	if (a&0b1111)+(n8&0b1111)+carry > 0b1111 {
+		flags.setH()
+	}
+	if uint16(a)+uint16(n8)+uint16(carry) > 0b1111_1111 {
+		flags.setC()
+	}
 }
 
-func returnFromFunction(memory *memory, programCounter *uint16, stackPointer *uint16) {
-	pc := *programCounter
+func subtractImpl(registerA uint8, n8 uint8, flags flagsPtr) uint8 {
+	result := registerA - n8
 
-	returnImpl(memory, programCounter, stackPointer)
+	flags.clear()
 
-	instructionLengthInBytes := 1
-	instruction := "RET"
-	description := "RET: Unconditional return from a function."
-	logInstruction(memory, pc, instructionLengthInBytes, instruction, description)
+	if result == 0 {
+		flags.setZ()
+	}
+	flags.setN()
+	if halfCarrySub(registerA, n8) {
+		flags.setH()
+	}
+	if carrySub(registerA, n8) {
+		flags.setC()
+	}
+
+	return result
 }
 
-func returnFromFunctionConditional(memory *memory, programCounter *uint16, stackPointer *uint16, condition bool, conditionName string) {
-	pc := *programCounter
+func subtractWithCarryImpl(registerA uint8, n8 uint8, flags flagsPtr) uint8 {
+	carry := uint8(0)
+	if flags.c() {
+		carry = 1
+	}
+	result := registerA - n8 - carry
 
-	if condition {
-		returnImpl(memory, programCounter, stackPointer)
+	flags.clear()
+	if result == 0 {
+		flags.setZ()
+	}
+	flags.setN()
+	if uint16(registerA&0b1111) < uint16(n8&0b1111)+uint16(carry) {
+		flags.setH()
+	}
+	if uint16(registerA) < uint16(n8)+uint16(carry) {
+		flags.setC()
 	}
 
-	instructionLengthInBytes := 1
-	instruction := fmt.Sprintf("RET %s", conditionName)
-	description := "RET cc: Conditional return from a function, depending on the condition cc."
-	logInstruction(memory, pc, instructionLengthInBytes, instruction, description)
+	return result
 }
 
-func bitwiseOrRegister(memory *memory, programCounter uint16, registerA *uint8, flags flagsPtr, register uint8, registerName string) {
-	result := *registerA | register
+func bitwiseAndImpl(registerA *uint8, n8 uint8, flags flagsPtr) {
+	result := *registerA & n8
 	*registerA = result
 
 	flags.clear()
 	if result == 0 {
 		flags.setZ()
 	}
-
-	instructionLengthInBytes := 1
-	instruction := fmt.Sprintf("OR A %s", registerName)
-	description := "Performs a bitwise OR operation between the 8-bit A register and the 8-bit register r, and stores the result back into the A register."
-	logInstruction(memory, programCounter, instructionLengthInBytes, instruction, description)
+	flags.setH()
 }
 
-func halfCarryAdd(a, b uint8) bool {
-	return (a&0b1111)+(b&0b1111) > 0b1111
-}
+func bitwiseXorImpl(registerA *uint8, n8 uint8, flags flagsPtr) {
+	result := *registerA ^ n8
+	*registerA = result
 
-func carryAdd(a, b uint8) bool {
-	return uint16(a)+uint16(b) > 0b1111_1111
+	flags.clear()
+	if result == 0 {
+		flags.setZ()
+	}
 }
 
-func addImpl(registerA *uint8, n8 uint8, flags flagsPtr) {
-	a := *registerA
-	result := a + n8
+func bitwiseOrImpl(registerA *uint8, n8 uint8, flags flagsPtr) {
+	result := *registerA | n8
 	*registerA = result
 
 	flags.clear()
-
 	if result == 0 {
 		flags.setZ()
 	}
-	flags.setN()
-	if halfCarryAdd(a, n8) {
-		flags.setH()
-	}
-	if carryAdd(a, n8) {
-		flags.setC()
-	}
 }
 
-func addRegister(memory *memory, programCounter uint16, registerA *uint8, register uint8, flags flagsPtr, registerName string) {
-	addImpl(registerA, register, flags)
-	instructionLengthInBytes := 1
-	instruction := fmt.Sprintf("ADD %s", registerName)
-	description := "Adds to the 8-bit A register, the 8-bit register r, and stores the result back into the A register."
-	logInstruction(memory, programCounter, instructionLengthInBytes, instruction, description)
+func (cpu *cpu) add(bus *Bus, operand operand8) {
+	addImpl(cpu.registers.aPtr(), cpu.read8(bus, operand), cpu.registers.flags())
 }
 
-func addIndirectHL(memory *memory, programCounter uint16, registerA *uint8, registerHL uint16, flags flagsPtr) {
-	n8 := memory.read(registerHL)
-	addImpl(registerA, n8, flags)
+func (cpu *cpu) adc(bus *Bus, operand operand8) {
+	addWithCarryImpl(cpu.registers.aPtr(), cpu.read8(bus, operand), cpu.registers.flags())
+}
 
-	instructionLengthInBytes := 1
-	instruction := "ADD [HL]"
-	description := "Adds from the 8-bit A register, data from the absolute address speciﬁed by the 16-bit register HL, and stores the result back into the A register."
-	logInstruction(memory, programCounter, instructionLengthInBytes, instruction, description)
+func (cpu *cpu) sub(bus *Bus, operand operand8) {
+	*cpu.registers.aPtr() = subtractImpl(cpu.registers.a(), cpu.read8(bus, operand), cpu.registers.flags())
 }
 
-func addImmediate(memory *memory, programCounter *uint16, registerA *uint8, flags flagsPtr) {
-	pc := *programCounter
-	n8 := readUnsigned8(memory, programCounter)
-	addImpl(registerA, n8, flags)
-	instructionLengthInBytes := 2
-	instruction := fmt.Sprintf("ADD 0x%02X", n8)
-	description := "Adds from the 8-bit A register, the immediate data n, and stores the result back into the A register."
-	logInstruction(memory, pc, instructionLengthInBytes, instruction, description)
+func (cpu *cpu) sbc(bus *Bus, operand operand8) {
+	*cpu.registers.aPtr() = subtractWithCarryImpl(cpu.registers.a(), cpu.read8(bus, operand), cpu.registers.flags())
 }
 
-func halfCarrySub(a, b uint8) bool {
-	return (a & 0b1111) < (b & 0b1111)
+func (cpu *cpu) and(bus *Bus, operand operand8) {
+	bitwiseAndImpl(cpu.registers.aPtr(), cpu.read8(bus, operand), cpu.registers.flags())
 }
 
-func carrySub(a, b uint8) bool {
-	return a < b
+func (cpu *cpu) xor(bus *Bus, operand operand8) {
+	bitwiseXorImpl(cpu.registers.aPtr(), cpu.read8(bus, operand), cpu.registers.flags())
 }
 
-func subtractImpl(registerA uint8, n8 uint8, flags flagsPtr) uint8 {
-	result := registerA - n8
+func (cpu *cpu) or(bus *Bus, operand operand8) {
+	bitwiseOrImpl(cpu.registers.aPtr(), cpu.read8(bus, operand), cpu.registers.flags())
+}
+
+// cp is basically identical to sub, but does not update the A register.
+func (cpu *cpu) cp(bus *Bus, operand operand8) {
+	subtractImpl(cpu.registers.a(), cpu.read8(bus, operand), cpu.registers.flags())
+}
+
+// daa decimal adjusts the A register after a BCD addition or subtraction.
+func (cpu *cpu) daa() {
+	// https://ehaskins.com/2018-01-30%20Z80%20DAA/
+	flags := cpu.registers.flags()
+	a := cpu.registers.a()
+	correction := uint8(0)
+	carry := false
+
+	if flags.h() || (!flags.n() && a&0x0F > 0x09) {
+		correction |= 0x06
+	}
+	if flags.c() || (!flags.n() && a > 0x99) {
+		correction |= 0x60
+		carry = true
+	}
+
+	if flags.n() {
+		a -= correction
+	} else {
+		a += correction
+	}
+	*cpu.registers.aPtr() = a
 
 	flags.clear()
+	if a == 0 {
+		flags.setZ()
+	}
+	if carry {
+		flags.setC()
+	}
+}
+
+// cpl flips all the bits in the A register.
+func (cpu *cpu) cpl() {
+	*cpu.registers.aPtr() = ^cpu.registers.a()
+}
 
+// scf sets the carry flag.
+func (cpu *cpu) scf() {
+	cpu.registers.flags().setC()
+}
+
+// ccf flips the carry flag.
+func (cpu *cpu) ccf() {
+	flags := cpu.registers.flags()
+	if flags.c() {
+		clearBit4(flags.data)
+	} else {
+		flags.setC()
+	}
+}
+
+func shiftFlags(result uint8, carry bool, flags flagsPtr) {
+	flags.clear()
 	if result == 0 {
 		flags.setZ()
 	}
-	flags.setN()
-	if halfCarrySub(registerA, n8) {
-		flags.setH()
-	}
-	if carrySub(registerA, n8) {
+	if carry {
 		flags.setC()
 	}
+}
+
+func rotateLeftCircularImpl(value uint8, flags flagsPtr) uint8 {
+	result := value<<1 | value>>7
+	shiftFlags(result, isBit7Set(value), flags)
+	return result
+}
+
+func rotateRightCircularImpl(value uint8, flags flagsPtr) uint8 {
+	result := value>>1 | value<<7
+	shiftFlags(result, value&0b0000_0001 != 0, flags)
+	return result
+}
+
+func rotateLeftImpl(value uint8, flags flagsPtr) uint8 {
+	result := value << 1
+	if flags.c() {
+		result |= 0b0000_0001
+	}
+	shiftFlags(result, isBit7Set(value), flags)
+	return result
+}
+
+func rotateRightImpl(value uint8, flags flagsPtr) uint8 {
+	result := value >> 1
+	if flags.c() {
+		result |= 0b1000_0000
+	}
+	shiftFlags(result, value&0b0000_0001 != 0, flags)
+	return result
+}
+
+func shiftLeftArithmeticImpl(value uint8, flags flagsPtr) uint8 {
+	result := value << 1
+	shiftFlags(result, isBit7Set(value), flags)
+	return result
+}
 
+func shiftRightArithmeticImpl(value uint8, flags flagsPtr) uint8 {
+	// bit 7 is kept, so the sign of the value is preserved
+	result := value>>1 | value&0b1000_0000
+	shiftFlags(result, value&0b0000_0001 != 0, flags)
 	return result
 }
 
-func subtractRegister(memory *memory, programCounter uint16, registerA *uint8, register uint8, flags flagsPtr, registerName string) {
-	res := subtractImpl(*registerA, register, flags)
-	*registerA = res
-	instructionLengthInBytes := 1
-	instruction := fmt.Sprintf("SUB %s", registerName)
-	description := "Subtracts from the 8-bit A register, the 8-bit register r, and stores the result back into the A register."
-	logInstruction(memory, programCounter, instructionLengthInBytes, instruction, description)
+func swapImpl(value uint8, flags flagsPtr) uint8 {
+	result := value<<4 | value>>4
+	shiftFlags(result, false, flags)
+	return result
 }
 
-func subtractIndirectHL(memory *memory, programCounter uint16, registerA *uint8, registerHL uint16, flags flagsPtr) {
-	n8 := memory.read(registerHL)
-	res := subtractImpl(*registerA, n8, flags)
-	*registerA = res
+func shiftRightLogicalImpl(value uint8, flags flagsPtr) uint8 {
+	result := value >> 1
+	shiftFlags(result, value&0b0000_0001 != 0, flags)
+	return result
+}
 
-	instructionLengthInBytes := 1
-	instruction := "SUB [HL]"
-	description := "Subtracts from the 8-bit A register, data from the absolute address speciﬁed by the 16-bit register HL, and stores the result back into the A register."
-	logInstruction(memory, programCounter, instructionLengthInBytes, instruction, description)
+// shift applies one of the rotate/shift implementations to the operand. It serves the rotate and shift instructions
+// of the 0xCB page as well as RLCA, RRCA, RLA and RRA (for those the opcode table forces Z to 0).
+func (cpu *cpu) shift(bus *Bus, operand operand8, impl func(value uint8, flags flagsPtr) uint8) {
+	cpu.modify8(bus, operand, func(value uint8) uint8 {
+		return impl(value, cpu.registers.flags())
+	})
 }
 
-func subtractImmediate(memory *memory, programCounter *uint16, registerA *uint8, flags flagsPtr) {
-	pc := *programCounter
+// bit tests the bit of the operand and sets the zero flag if the bit is not set.
+func (cpu *cpu) bit(bus *Bus, bit uint8, operand operand8) {
+	flags := cpu.registers.flags()
+	flags.clear()
+	if cpu.read8(bus, operand)&(1<<bit) == 0 {
+		flags.setZ()
+	}
+}
 
-	n8 := readUnsigned8(memory, programCounter)
-	res := subtractImpl(*registerA, n8, flags)
-	*registerA = res
+// res sets the bit of the operand to 0.
+func (cpu *cpu) res(bus *Bus, bit uint8, operand operand8) {
+	cpu.modify8(bus, operand, func(value uint8) uint8 {
+		return value &^ (1 << bit)
+	})
+}
 
-	instructionLengthInBytes := 2
-	instruction := fmt.Sprintf("SUB 0x%02X", n8)
-	description := "Subtracts from the 8-bit A register, the immediate data n, and stores the result back into the A register."
-	logInstruction(memory, pc, instructionLengthInBytes, instruction, description)
+// set sets the bit of the operand to 1.
+func (cpu *cpu) set(bus *Bus, bit uint8, operand operand8) {
+	cpu.modify8(bus, operand, func(value uint8) uint8 {
+		return value | 1<<bit
+	})
 }
 
-func increment16BitRegister(memory *memory, programCounter uint16, register *uint16, registerName string) {
-	*register++
+// jp jumps to the absolute address a16 if the condition is met. It reports whether the jump was taken.
+func (cpu *cpu) jp(bus *Bus, cc condition) bool {
+	a16 := readUnsigned16(bus, &cpu.registers.pc)
+	if !cpu.conditionMet(cc) {
+		return false
+	}
+	cpu.registers.pc = a16
+	return true
+}
 
-	instructionLengthInBytes := 1
-	instruction := fmt.Sprintf("INC %s", registerName)
-	description := "INC rr: Increments data in the 16-bit register rr."
-	logInstruction(memory, programCounter, instructionLengthInBytes, instruction, description)
+// jpHL jumps to the absolute address in HL.
+func (cpu *cpu) jpHL() {
+	cpu.registers.pc = cpu.registers.hl
 }
 
-func decrement16BitRegister(memory *memory, programCounter uint16, register *uint16, registerName string) {
-	*register--
+// jr jumps relative to the address of the next instruction by the signed offset e8 if the condition is met. It
+// reports whether the jump was taken.
+func (cpu *cpu) jr(bus *Bus, cc condition) bool {
+	e8 := int8(readUnsigned8(bus, &cpu.registers.pc))
+	if !cpu.conditionMet(cc) {
+		return false
+	}
+	cpu.registers.pc = uint16(int32(cpu.registers.pc) + int32(e8))
+	return true
+}
 
-	instructionLengthInBytes := 1
-	instruction := fmt.Sprintf("DEC %s", registerName)
-	description := "DEC rr: Decrements data in the 16-bit register rr."
-	logInstruction(memory, programCounter, instructionLengthInBytes, instruction, description)
+// call pushes the address of the next instruction and jumps to the absolute address a16 if the condition is met. It
+// reports whether the call was taken.
+func (cpu *cpu) call(bus *Bus, cc condition) bool {
+	a16 := readUnsigned16(bus, &cpu.registers.pc)
+	if !cpu.conditionMet(cc) {
+		return false
+	}
+	push(bus, &cpu.registers.sp, cpu.registers.pc)
+	cpu.registers.pc = a16
+	return true
 }
 
-func disableInterrupts(memory *memory, programCounter uint16, ime *bool) {
-	*ime = false
+// ret pops the return address from the stack if the condition is met. It reports whether the return was taken.
+func (cpu *cpu) ret(bus *Bus, cc condition) bool {
+	if !cpu.conditionMet(cc) {
+		return false
+	}
+	cpu.registers.pc = pop(bus, &cpu.registers.sp)
+	return true
+}
+
+// reti returns from an interrupt handler and enables interrupts.
+func (cpu *cpu) reti(bus *Bus) {
+	cpu.registers.pc = pop(bus, &cpu.registers.sp)
+	cpu.ime = true
+}
 
-	instructionLengthInBytes := 1
-	instruction := "DI"
-	description := "Disables interrupt handling by setting IME=0 and cancelling any scheduled eﬀects of the EI instruction if any."
-	logInstruction(memory, programCounter, instructionLengthInBytes, instruction, description)
+// rst calls the fixed address defined by the opcode.
+func (cpu *cpu) rst(bus *Bus, vector uint16) {
+	push(bus, &cpu.registers.sp, cpu.registers.pc)
+	cpu.registers.pc = vector
 }
 
-func compareImmediate(memory *memory, programCounter *uint16, registerA uint8, flags flagsPtr) {
-	pc := *programCounter
+func (cpu *cpu) push16(bus *Bus, operand operand16) {
+	push(bus, &cpu.registers.sp, cpu.read16(bus, operand))
+}
 
-	n8 := readUnsigned8(memory, programCounter)
-	subtractImpl(registerA, n8, flags)
+func (cpu *cpu) pop16(bus *Bus, operand operand16) {
+	cpu.write16(bus, operand, pop(bus, &cpu.registers.sp))
+}
+
+// di disables interrupt handling by setting IME=0.
+func (cpu *cpu) di() {
+	cpu.ime = false
+	cpu.imeDelay = false
+}
 
-	instructionLengthInBytes := 2
-	instruction := spew.Sprintf("CP 0x%02X", n8)
-	description := "Subtracts from the 8-bit A register, the immediate data n, and updates ﬂags based on the result. This instruction is basically identical to SUB n, but does not update the A register."
-	logInstruction(memory, pc, instructionLengthInBytes, instruction, description)
+// ei enables interrupt handling by setting IME=1. IME is set after the following instruction.
+func (cpu *cpu) ei() {
+	cpu.imeDelay = true
+}
+
+// halt enters the cpu low-power consumption mode until an interrupt is pending. If IME is reset and an interrupt is
+// already pending, the cpu does not halt and the HALT bug occurs instead: the byte after HALT is read twice.
+func (cpu *cpu) halt(bus *Bus) {
+	if !cpu.ime && bus.interrupts != nil && bus.interrupts.pending() != 0 {
+		cpu.haltBug = true
+		return
+	}
+	cpu.halted = true
+}
+
+// stop enters the cpu very low power mode until a joypad button is pressed, or performs an armed CGB speed switch. The
+// opcode is followed by a padding byte that is skipped. STOP resets DIV.
+func (cpu *cpu) stop(bus *Bus) {
+	readUnsigned8(bus, &cpu.registers.pc)
+	bus.write(divAddress, 0)
+	if bus.speed != nil && bus.speed.switchSpeed() {
+		return
+	}
+	cpu.stopped = true
 }
@@ -0,0 +1,88 @@
+package internal
+
+const (
+	interruptFlagAddress   uint16 = 0xFF0F // IF: requested interrupts
+	interruptEnableAddress uint16 = 0xFFFF // IE: enabled interrupts
+)
+
+// interrupt is the bit of an interrupt source in IE and IF. The bit position also defines the priority, bit 0 has the
+// highest priority.
+type interrupt uint8
+
+const (
+	vBlankInterrupt interrupt = 1 << iota
+	lcdInterrupt
+	timerInterrupt
+	serialInterrupt
+	joypadInterrupt
+)
+
+// interruptMask covers the five interrupt sources, the upper bits of IE and IF are not used.
+const interruptMask uint8 = 0b0001_1111
+
+// interruptDispatchCycles is the number of T-cycles (5 M-cycles) the cpu takes to call an interrupt handler.
+const interruptDispatchCycles = 20
+
+// interrupts holds the IE and IF registers. Components request interrupts by setting their bit in IF.
+type interrupts struct {
+	enable uint8
+	flag   uint8
+}
+
+// request sets the bit of the interrupt in IF.
+func (i *interrupts) request(interrupt interrupt) {
+	i.flag |= uint8(interrupt)
+}
+
+// pending returns the interrupts that are both requested and enabled.
+func (i *interrupts) pending() uint8 {
+	return i.enable & i.flag & interruptMask
+}
+
+// next returns the pending interrupt with the highest priority and its handler address. ok is false if no interrupt
+// is pending.
+func (i *interrupts) next() (interrupt interrupt, vector uint16, ok bool) {
+	pending := i.pending()
+	for bit := uint16(0); bit < 5; bit++ {
+		if pending&(1<<bit) != 0 {
+			return 1 << bit, 0x40 + bit*8, true
+		}
+	}
+	return 0, 0, false
+}
+
+func (i *interrupts) read(address uint16) uint8 {
+	if address == interruptFlagAddress {
+		// the upper three bits of IF are unused and read as 1
+		return i.flag | ^interruptMask
+	}
+	return i.enable
+}
+
+func (i *interrupts) write(address uint16, value uint8) {
+	if address == interruptFlagAddress {
+		i.flag = value & interruptMask
+		return
+	}
+	i.enable = value
+}
+
+// serviceInterrupt dispatches the pending interrupt with the highest priority if IME is set: IME is cleared, the
+// request is acknowledged in IF and PC is pushed before jumping to the handler. It returns the number of T-cycles
+// taken, zero if no interrupt was dispatched.
+func (cpu *cpu) serviceInterrupt(bus *Bus) int {
+	if !cpu.ime || bus.interrupts == nil {
+		return 0
+	}
+
+	interrupt, vector, ok := bus.interrupts.next()
+	if !ok {
+		return 0
+	}
+
+	cpu.ime = false
+	bus.interrupts.flag &^= uint8(interrupt)
+	push(bus, &cpu.registers.sp, cpu.registers.pc)
+	cpu.registers.pc = vector
+	return interruptDispatchCycles
+}
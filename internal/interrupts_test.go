@@ -0,0 +1,114 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInterruptDispatch(t *testing.T) {
+	gb := newTestGameBoy([]byte{0x00})
+	gb.cpu.ime = true
+	gb.bus.write(interruptEnableAddress, 0xFF)
+	gb.interrupts.request(timerInterrupt)
+
+	cycles := gb.step()
+
+	assert.Equal(t, interruptDispatchCycles, cycles)
+	assert.Equal(t, uint16(0x50), gb.cpu.registers.pc)
+	assert.Equal(t, uint16(0x0100), pop(&gb.bus, &gb.cpu.registers.sp))
+	assert.False(t, gb.cpu.ime)
+	assert.Equal(t, uint8(0b1110_0000), gb.bus.read(interruptFlagAddress))
+}
+
+func TestInterruptPriority(t *testing.T) {
+	gb := newTestGameBoy([]byte{0x00})
+	gb.cpu.ime = true
+	gb.bus.write(interruptEnableAddress, uint8(lcdInterrupt|joypadInterrupt))
+	gb.interrupts.request(joypadInterrupt)
+	gb.interrupts.request(lcdInterrupt)
+	gb.interrupts.request(vBlankInterrupt) // not enabled
+
+	gb.step()
+
+	assert.Equal(t, uint16(0x48), gb.cpu.registers.pc)
+	assert.Equal(t, uint8(vBlankInterrupt|joypadInterrupt), gb.interrupts.flag)
+}
+
+func TestEnableInterruptsDelay(t *testing.T) {
+	// EI; INC A; INC A
+	gb := newTestGameBoy([]byte{0xFB, 0x3C, 0x3C})
+	gb.bus.write(interruptEnableAddress, uint8(vBlankInterrupt))
+	gb.interrupts.request(vBlankInterrupt)
+
+	gb.step()
+	assert.Equal(t, uint16(0x0101), gb.cpu.registers.pc)
+
+	// the instruction following EI runs before the interrupt is dispatched
+	gb.step()
+	assert.Equal(t, uint8(1), gb.cpu.registers.a())
+
+	gb.step()
+	assert.Equal(t, uint16(0x40), gb.cpu.registers.pc)
+}
+
+func TestEnableDisableInterrupts(t *testing.T) {
+	// EI; DI; NOP
+	gb := newTestGameBoy([]byte{0xFB, 0xF3, 0x00})
+	gb.bus.write(interruptEnableAddress, uint8(vBlankInterrupt))
+	gb.interrupts.request(vBlankInterrupt)
+
+	for i := 0; i < 3; i++ {
+		gb.step()
+	}
+	assert.False(t, gb.cpu.ime)
+	assert.Equal(t, uint16(0x0103), gb.cpu.registers.pc)
+}
+
+func TestReturnFromInterrupt(t *testing.T) {
+	// RETI at the vblank vector
+	gb := newTestGameBoy([]byte{0x00})
+	pokeROM(&gb.bus, 0x40, 0xD9)
+	gb.cpu.ime = true
+	gb.bus.write(interruptEnableAddress, uint8(vBlankInterrupt))
+	gb.interrupts.request(vBlankInterrupt)
+
+	gb.step()
+	gb.step()
+
+	assert.Equal(t, uint16(0x0100), gb.cpu.registers.pc)
+	assert.True(t, gb.cpu.ime)
+}
+
+func TestHaltWakesOnInterrupt(t *testing.T) {
+	// HALT; INC A
+	gb := newTestGameBoy([]byte{0x76, 0x3C})
+	gb.bus.write(interruptEnableAddress, uint8(timerInterrupt))
+
+	gb.step()
+	assert.True(t, gb.cpu.halted)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, lowPowerCycles, gb.step())
+	}
+	assert.Equal(t, uint16(0x0101), gb.cpu.registers.pc)
+
+	// with IME reset, the cpu continues after HALT without calling the handler
+	gb.interrupts.request(timerInterrupt)
+	gb.step()
+	assert.False(t, gb.cpu.halted)
+	assert.Equal(t, uint8(1), gb.cpu.registers.a())
+}
+
+func TestHaltBug(t *testing.T) {
+	// HALT; INC A; NOP
+	gb := newTestGameBoy([]byte{0x76, 0x3C, 0x00})
+	gb.bus.write(interruptEnableAddress, uint8(timerInterrupt))
+	gb.interrupts.request(timerInterrupt)
+
+	for i := 0; i < 3; i++ {
+		gb.step()
+	}
+	assert.False(t, gb.cpu.halted)
+	// INC A is executed twice
+	assert.Equal(t, uint8(2), gb.cpu.registers.a())
+	assert.Equal(t, uint16(0x0102), gb.cpu.registers.pc)
+}
@@ -0,0 +1,103 @@
+package internal
+
+import "bytes"
+
+// mbc1 is the MBC1 memory bank controller for up to 2 MiB ROM and 32 KiB RAM.
+// https://gbdev.io/pandocs/MBC1.html
+type mbc1 struct {
+	rom []byte
+	ram []byte
+
+	ramEnabled bool
+	bank1      uint8 // bank1 is the 5-bit lower ROM bank number (0x2000-0x3FFF)
+	bank2      uint8 // bank2 is the 2-bit upper ROM bank number or the RAM bank number (0x4000-0x5FFF)
+	mode       uint8 // mode selects simple (0) or advanced (1) banking (0x6000-0x7FFF)
+
+	// bank1Bits is the number of bits of bank1 used for the ROM bank, 4 on MBC1M multicarts where bank2 selects the game
+	bank1Bits uint
+}
+
+func newMBC1(rom, ram []byte) *mbc1 {
+	m := &mbc1{rom: rom, ram: ram, bank1: 1, bank1Bits: 5}
+	if isMBC1Multicart(rom) {
+		m.bank1Bits = 4
+	}
+	return m
+}
+
+// isMBC1Multicart detects MBC1M multicarts. They are 1 MiB and contain several games of 256 KiB each, every one of
+// them starting with a header including the Nintendo logo.
+func isMBC1Multicart(rom []byte) bool {
+	const gameSize = 0x40000
+	if len(rom) != 4*gameSize {
+		return false
+	}
+
+	logos := 0
+	for game := 0; game < 4; game++ {
+		offset := game*gameSize + 0x0104
+		if bytes.Equal(rom[offset:offset+len(nintendoLogo)], nintendoLogo[:]) {
+			logos++
+		}
+	}
+	return logos > 1
+}
+
+func (m *mbc1) saveRAM() []byte {
+	return m.ram
+}
+
+func (m *mbc1) mappedROMBank() int {
+	bank1 := m.bank1 & (1<<m.bank1Bits - 1)
+	return wrapROMBank(m.rom, int(m.bank2)<<m.bank1Bits|int(bank1))
+}
+
+func (m *mbc1) ReadROM(address uint16) uint8 {
+	if address < 0x4000 {
+		bank := 0
+		if m.mode == 1 {
+			bank = int(m.bank2) << m.bank1Bits
+		}
+		return romBank(m.rom, bank, address)
+	}
+	return romBank(m.rom, m.mappedROMBank(), address-0x4000)
+}
+
+func (m *mbc1) WriteROM(address uint16, value uint8) {
+	switch {
+	case address < 0x2000:
+		m.ramEnabled = value&0x0F == 0x0A
+	case address < 0x4000:
+		// bank 0 can't be selected in the 5-bit register, it is mapped to bank 1. As only the 5-bit register is checked,
+		// the banks 0x20, 0x40 and 0x60 are mapped to 0x21, 0x41 and 0x61.
+		m.bank1 = value & 0b1_1111
+		if m.bank1 == 0 {
+			m.bank1 = 1
+		}
+	case address < 0x6000:
+		m.bank2 = value & 0b11
+	default:
+		m.mode = value & 0b1
+	}
+}
+
+func (m *mbc1) ramBank() int {
+	if m.mode == 1 {
+		return int(m.bank2)
+	}
+	return 0
+}
+
+func (m *mbc1) ReadRAM(address uint16) uint8 {
+	if !m.ramEnabled || len(m.ram) == 0 {
+		return openBus
+	}
+	return m.ram[ramIndex(m.ram, m.ramBank(), address-0xA000)]
+}
+
+func (m *mbc1) WriteRAM(address uint16, value uint8) {
+	if !m.ramEnabled || len(m.ram) == 0 {
+		return
+	}
+	m.ram[ramIndex(m.ram, m.ramBank(), address-0xA000)] = value
+}
@@ -0,0 +1,91 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// newTestROM returns a rom of the given number of banks with the bank number written to the first byte of each bank.
+func newTestROM(cartridgeType, ramSizeCode byte, banks int) []byte {
+	rom := make([]byte, banks*romBankSize)
+	for bank := 0; bank < banks; bank++ {
+		rom[bank*romBankSize] = byte(bank)
+	}
+	rom[cartridgeTypeAddress] = cartridgeType
+	rom[ramSizeAddress] = ramSizeCode
+	return rom
+}
+
+func TestNewCartridge(t *testing.T) {
+	cartridge, err := NewCartridge(newTestROM(0x00, 0x00, 2))
+	assert.NoError(t, err)
+	assert.IsType(t, &romOnly{}, cartridge)
+
+	cartridge, err = NewCartridge(newTestROM(0x03, 0x03, 4))
+	assert.NoError(t, err)
+	assert.IsType(t, &mbc1{}, cartridge)
+	assert.Len(t, cartridge.(*mbc1).ram, 32*1024)
+
+	_, err = NewCartridge(newTestROM(0xFC, 0x00, 2))
+	assert.Error(t, err)
+}
+
+func TestMBC1ROMBanking(t *testing.T) {
+	cartridge, _ := NewCartridge(newTestROM(0x01, 0x00, 128))
+
+	assert.Equal(t, uint8(1), cartridge.ReadROM(0x4000))
+	cartridge.WriteROM(0x2000, 0x05)
+	assert.Equal(t, uint8(5), cartridge.ReadROM(0x4000))
+
+	// bank 0 is mapped to bank 1
+	cartridge.WriteROM(0x2000, 0x00)
+	assert.Equal(t, uint8(1), cartridge.ReadROM(0x4000))
+
+	// banks 0x20, 0x40 and 0x60 are mapped to 0x21, 0x41 and 0x61
+	for _, bank2 := range []uint8{1, 2, 3} {
+		cartridge.WriteROM(0x4000, bank2)
+		assert.Equal(t, bank2<<5|1, cartridge.ReadROM(0x4000))
+	}
+
+	// in advanced mode, bank2 also applies to 0x0000-0x3FFF
+	assert.Equal(t, uint8(0), cartridge.ReadROM(0x0000))
+	cartridge.WriteROM(0x6000, 0x01)
+	assert.Equal(t, uint8(0x60), cartridge.ReadROM(0x0000))
+}
+
+func TestMBC1RAMBanking(t *testing.T) {
+	cartridge, _ := NewCartridge(newTestROM(0x03, 0x03, 4))
+
+	// disabled RAM is not writable and reads open bus
+	cartridge.WriteRAM(0xA000, 0x42)
+	assert.Equal(t, openBus, cartridge.ReadRAM(0xA000))
+
+	cartridge.WriteROM(0x0000, 0x0A)
+	cartridge.WriteRAM(0xA000, 0x42)
+	assert.Equal(t, uint8(0x42), cartridge.ReadRAM(0xA000))
+
+	// RAM banks are only switched in advanced mode
+	cartridge.WriteROM(0x4000, 0x02)
+	assert.Equal(t, uint8(0x42), cartridge.ReadRAM(0xA000))
+	cartridge.WriteROM(0x6000, 0x01)
+	assert.Equal(t, uint8(0x00), cartridge.ReadRAM(0xA000))
+	cartridge.WriteRAM(0xA000, 0x24)
+	assert.Equal(t, uint8(0x24), cartridge.(*mbc1).ram[2*ramBankSize])
+}
+
+func TestMBC1Multicart(t *testing.T) {
+	rom := newTestROM(0x01, 0x00, 64)
+	for game := 0; game < 4; game++ {
+		copy(rom[game*0x40000+0x0104:], nintendoLogo[:])
+	}
+
+	cartridge, _ := NewCartridge(rom)
+	assert.Equal(t, uint(4), cartridge.(*mbc1).bank1Bits)
+
+	// bank2 selects the game, bank1 the 4-bit bank within the game
+	cartridge.WriteROM(0x4000, 0x01)
+	cartridge.WriteROM(0x2000, 0x12)
+	assert.Equal(t, uint8(0x12), cartridge.ReadROM(0x4000))
+	cartridge.WriteROM(0x6000, 0x01)
+	assert.Equal(t, uint8(0x10), cartridge.ReadROM(0x0000))
+}
@@ -0,0 +1,64 @@
+package internal
+
+// mbc2RAMSize is the number of 4-bit values of the built-in RAM of MBC2.
+const mbc2RAMSize = 512
+
+// mbc2 is the MBC2 memory bank controller for up to 256 KiB ROM with 512x4 bits of built-in RAM.
+// https://gbdev.io/pandocs/MBC2.html
+type mbc2 struct {
+	rom []byte
+	ram []byte // ram holds one 4-bit value per byte in the lower nibble
+
+	ramEnabled bool
+	romBank    uint8
+}
+
+func newMBC2(rom []byte) *mbc2 {
+	return &mbc2{rom: rom, ram: make([]byte, mbc2RAMSize), romBank: 1}
+}
+
+func (m *mbc2) saveRAM() []byte {
+	return m.ram
+}
+
+func (m *mbc2) mappedROMBank() int {
+	return wrapROMBank(m.rom, int(m.romBank))
+}
+
+func (m *mbc2) ReadROM(address uint16) uint8 {
+	if address < 0x4000 {
+		return romBank(m.rom, 0, address)
+	}
+	return romBank(m.rom, int(m.romBank), address-0x4000)
+}
+
+func (m *mbc2) WriteROM(address uint16, value uint8) {
+	if address >= 0x4000 {
+		return
+	}
+	// bit 8 of the address selects the register
+	if address&0x0100 == 0 {
+		m.ramEnabled = value&0x0F == 0x0A
+		return
+	}
+	m.romBank = value & 0x0F
+	if m.romBank == 0 {
+		m.romBank = 1
+	}
+}
+
+func (m *mbc2) ReadRAM(address uint16) uint8 {
+	if !m.ramEnabled {
+		return openBus
+	}
+	// only the lower 9 bits of the address are used, the RAM is echoed across 0xA000-0xBFFF; the upper nibble is
+	// not connected and reads as 1
+	return m.ram[address&(mbc2RAMSize-1)] | 0xF0
+}
+
+func (m *mbc2) WriteRAM(address uint16, value uint8) {
+	if !m.ramEnabled {
+		return
+	}
+	m.ram[address&(mbc2RAMSize-1)] = value & 0x0F
+}
@@ -0,0 +1,35 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMBC2Registers(t *testing.T) {
+	cartridge, err := NewCartridge(newTestROM(0x06, 0x00, 16))
+	assert.NoError(t, err)
+
+	// bit 8 set selects the ROM bank register
+	cartridge.WriteROM(0x2100, 0x0F)
+	assert.Equal(t, uint8(0x0F), cartridge.ReadROM(0x4000))
+	cartridge.WriteROM(0x0100, 0x00)
+	assert.Equal(t, uint8(0x01), cartridge.ReadROM(0x4000))
+
+	// bit 8 reset selects RAM enable, even in 0x2000-0x3FFF
+	assert.Equal(t, openBus, cartridge.ReadRAM(0xA000))
+	cartridge.WriteROM(0x3000, 0x0A)
+	cartridge.WriteRAM(0xA000, 0x00)
+	assert.Equal(t, uint8(0xF0), cartridge.ReadRAM(0xA000))
+	assert.Equal(t, uint8(0x01), cartridge.ReadROM(0x4000))
+}
+
+func TestMBC2RAM(t *testing.T) {
+	cartridge, _ := NewCartridge(newTestROM(0x05, 0x00, 4))
+	cartridge.WriteROM(0x0000, 0x0A)
+
+	cartridge.WriteRAM(0xA1FF, 0x3C)
+	assert.Equal(t, uint8(0xFC), cartridge.ReadRAM(0xA1FF))
+	// echoed every 512 bytes
+	assert.Equal(t, uint8(0xFC), cartridge.ReadRAM(0xA3FF))
+	assert.Equal(t, uint8(0xFC), cartridge.ReadRAM(0xBFFF))
+}
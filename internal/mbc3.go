@@ -0,0 +1,105 @@
+package internal
+
+// mbc3 is the MBC3 memory bank controller for up to 2 MiB ROM and 32 KiB RAM, optionally with a real-time clock.
+// https://gbdev.io/pandocs/MBC3.html
+type mbc3 struct {
+	rom []byte
+	ram []byte
+	rtc *rtc // rtc is nil for cartridges without timer
+
+	ramAndTimerEnabled bool
+	romBank            uint8
+	ramBankOrRTC       uint8 // ramBankOrRTC selects a RAM bank (0x00-0x07) or an RTC register (0x08-0x0C)
+}
+
+func newMBC3(rom, ram []byte, rtc *rtc) *mbc3 {
+	return &mbc3{rom: rom, ram: ram, rtc: rtc, romBank: 1}
+}
+
+func (m *mbc3) tick(cycles int) {
+	if m.rtc != nil {
+		m.rtc.tick(cycles)
+	}
+}
+
+func (m *mbc3) saveRAM() []byte {
+	return m.ram
+}
+
+func (m *mbc3) saveRTC() []byte {
+	if m.rtc == nil {
+		return nil
+	}
+	return m.rtc.save()
+}
+
+func (m *mbc3) loadRTC(block []byte) error {
+	if m.rtc == nil {
+		return nil
+	}
+	return m.rtc.load(block)
+}
+
+func (m *mbc3) mappedROMBank() int {
+	return wrapROMBank(m.rom, int(m.romBank))
+}
+
+func (m *mbc3) ReadROM(address uint16) uint8 {
+	if address < 0x4000 {
+		return romBank(m.rom, 0, address)
+	}
+	return romBank(m.rom, int(m.romBank), address-0x4000)
+}
+
+func (m *mbc3) WriteROM(address uint16, value uint8) {
+	switch {
+	case address < 0x2000:
+		m.ramAndTimerEnabled = value&0x0F == 0x0A
+	case address < 0x4000:
+		m.romBank = value & 0b111_1111
+		if m.romBank == 0 {
+			m.romBank = 1
+		}
+	case address < 0x6000:
+		m.ramBankOrRTC = value
+	default:
+		if m.rtc != nil {
+			m.rtc.writeLatch(value)
+		}
+	}
+}
+
+// rtcRegister returns the selected RTC register, ok is false if a RAM bank is selected.
+func (m *mbc3) rtcRegister() (register int, ok bool) {
+	if m.rtc == nil || m.ramBankOrRTC < 0x08 || m.ramBankOrRTC > 0x0C {
+		return 0, false
+	}
+	return int(m.ramBankOrRTC - 0x08), true
+}
+
+func (m *mbc3) ReadRAM(address uint16) uint8 {
+	if !m.ramAndTimerEnabled {
+		return openBus
+	}
+	if register, ok := m.rtcRegister(); ok {
+		return m.rtc.read(register)
+	}
+	if len(m.ram) == 0 || m.ramBankOrRTC > 0x07 {
+		return openBus
+	}
+	return m.ram[ramIndex(m.ram, int(m.ramBankOrRTC), address-0xA000)]
+}
+
+func (m *mbc3) WriteRAM(address uint16, value uint8) {
+	if !m.ramAndTimerEnabled {
+		return
+	}
+	if register, ok := m.rtcRegister(); ok {
+		m.rtc.write(register, value)
+		return
+	}
+	if len(m.ram) == 0 || m.ramBankOrRTC > 0x07 {
+		return
+	}
+	m.ram[ramIndex(m.ram, int(m.ramBankOrRTC), address-0xA000)] = value
+}
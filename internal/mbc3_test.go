@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMBC3Banking(t *testing.T) {
+	cartridge, err := NewCartridge(newTestROM(0x13, 0x03, 128))
+	assert.NoError(t, err)
+
+	cartridge.WriteROM(0x2000, 0x7F)
+	assert.Equal(t, uint8(0x7F), cartridge.ReadROM(0x4000))
+	cartridge.WriteROM(0x2000, 0x00)
+	assert.Equal(t, uint8(0x01), cartridge.ReadROM(0x4000))
+
+	cartridge.WriteROM(0x0000, 0x0A)
+	cartridge.WriteROM(0x4000, 0x03)
+	cartridge.WriteRAM(0xA000, 0x42)
+	assert.Equal(t, uint8(0x42), cartridge.(*mbc3).ram[3*ramBankSize])
+
+	// without timer, the RTC registers are not mapped
+	cartridge.WriteROM(0x4000, 0x08)
+	assert.Equal(t, openBus, cartridge.ReadRAM(0xA000))
+}
+
+func latchRTC(cartridge Cartridge) {
+	cartridge.WriteROM(0x6000, 0x00)
+	cartridge.WriteROM(0x6000, 0x01)
+}
+
+func readRTC(cartridge Cartridge, register uint8) uint8 {
+	cartridge.WriteROM(0x4000, 0x08+register)
+	return cartridge.ReadRAM(0xA000)
+}
+
+func TestMBC3ClockEmulatedTime(t *testing.T) {
+	cartridge, _ := NewCartridge(newTestROM(0x10, 0x03, 4))
+	cartridge.WriteROM(0x0000, 0x0A)
+
+	clock := cartridge.(cartridgeClock)
+	clock.tick(61 * clockSpeed)
+
+	// the registers only change when latched
+	assert.Equal(t, uint8(0), readRTC(cartridge, rtcSeconds))
+	latchRTC(cartridge)
+	assert.Equal(t, uint8(1), readRTC(cartridge, rtcSeconds))
+	assert.Equal(t, uint8(1), readRTC(cartridge, rtcMinutes))
+
+	// halted clocks don't advance
+	cartridge.WriteROM(0x4000, 0x08+rtcDaysHigh)
+	cartridge.WriteRAM(0xA000, 0b0100_0000)
+	clock.tick(10 * clockSpeed)
+	latchRTC(cartridge)
+	assert.Equal(t, uint8(1), readRTC(cartridge, rtcSeconds))
+}
+
+func TestRTCDayCarry(t *testing.T) {
+	r := newRTC(false, time.Now)
+	r.write(rtcDaysLow, 0xFF)
+	r.write(rtcDaysHigh, 0x01)
+	r.write(rtcHours, 23)
+	r.write(rtcMinutes, 59)
+	r.write(rtcSeconds, 59)
+
+	r.tick(clockSpeed)
+	r.writeLatch(0x00)
+	r.writeLatch(0x01)
+
+	assert.Equal(t, [rtcRegisterCount]uint8{0, 0, 0, 0, 0b1000_0000}, r.latched)
+}
+
+func TestRTCOutOfRangeValues(t *testing.T) {
+	r := newRTC(false, time.Now)
+	r.write(rtcSeconds, 63)
+	r.advance(1)
+	// 63 overflows the 6 bits instead of wrapping at 60
+	assert.Equal(t, uint8(0), r.seconds)
+	assert.Equal(t, uint8(0), r.minutes)
+}
+
+func TestRTCAdvanceLongTime(t *testing.T) {
+	r := newRTC(false, time.Now)
+	r.write(rtcHours, 25)
+	// 25 counts up to 31 and overflows to 0 after 7 hours, then 600 days, 1 hour, 2 minutes and 3 seconds follow
+	r.advance(7*3600 + 600*86400 + 3600 + 2*60 + 3)
+	assert.Equal(t, [rtcRegisterCount]uint8{3, 2, 1, 600 - 512, 0b1000_0000}, r.registers())
+}
+
+func TestRTCLoadInvalidTimestamp(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	for _, timestamp := range []int64{0, now.Add(time.Hour).Unix()} {
+		block := make([]byte, rtcSaveSize)
+		block[4*rtcMinutes] = 10
+		binary.LittleEndian.PutUint64(block[8*rtcRegisterCount:], uint64(timestamp))
+
+		r := newRTC(true, func() time.Time { return now })
+		assert.NoError(t, r.load(block))
+		assert.Equal(t, [rtcRegisterCount]uint8{0, 10, 0, 0, 0}, r.registers())
+	}
+}
+
+func TestMBC3ClockWallClock(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cartridge, _ := NewCartridge(newTestROM(0x0F, 0x00, 4), WithWallClockRTC(), func(options *cartridgeOptions) {
+		options.now = func() time.Time { return now }
+	})
+	cartridge.WriteROM(0x0000, 0x0A)
+
+	// emulated time is ignored
+	cartridge.(cartridgeClock).tick(5 * clockSpeed)
+	now = now.Add(2*time.Hour + 1500*time.Millisecond)
+	latchRTC(cartridge)
+
+	assert.Equal(t, uint8(1), readRTC(cartridge, rtcSeconds))
+	assert.Equal(t, uint8(2), readRTC(cartridge, rtcHours))
+}
@@ -0,0 +1,78 @@
+package internal
+
+// mbc5 is the MBC5 memory bank controller for up to 8 MiB ROM and 128 KiB RAM, optionally with a rumble motor.
+// https://gbdev.io/pandocs/MBC5.html
+type mbc5 struct {
+	rom []byte
+	ram []byte
+
+	ramEnabled bool
+	romBank    uint16 // romBank is the 9-bit ROM bank number, bank 0 can be selected
+	ramBank    uint8
+
+	hasRumble bool
+	rumble    bool
+	onRumble  func(on bool)
+}
+
+func newMBC5(rom, ram []byte, hasRumble bool) *mbc5 {
+	return &mbc5{rom: rom, ram: ram, romBank: 1, hasRumble: hasRumble}
+}
+
+func (m *mbc5) setRumbleHandler(handler func(on bool)) {
+	m.onRumble = handler
+}
+
+func (m *mbc5) saveRAM() []byte {
+	return m.ram
+}
+
+func (m *mbc5) mappedROMBank() int {
+	return wrapROMBank(m.rom, int(m.romBank))
+}
+
+func (m *mbc5) ReadROM(address uint16) uint8 {
+	if address < 0x4000 {
+		return romBank(m.rom, 0, address)
+	}
+	return romBank(m.rom, int(m.romBank), address-0x4000)
+}
+
+func (m *mbc5) WriteROM(address uint16, value uint8) {
+	switch {
+	case address < 0x2000:
+		m.ramEnabled = value == 0x0A
+	case address < 0x3000:
+		m.romBank = m.romBank&0x100 | uint16(value)
+	case address < 0x4000:
+		m.romBank = uint16(value&0b1)<<8 | m.romBank&0xFF
+	case address < 0x6000:
+		if !m.hasRumble {
+			m.ramBank = value & 0x0F
+			return
+		}
+		// bit 3 drives the rumble motor instead of selecting RAM banks
+		m.ramBank = value & 0x07
+		rumble := value&0b1000 != 0
+		if rumble != m.rumble {
+			m.rumble = rumble
+			if m.onRumble != nil {
+				m.onRumble(rumble)
+			}
+		}
+	}
+}
+
+func (m *mbc5) ReadRAM(address uint16) uint8 {
+	if !m.ramEnabled || len(m.ram) == 0 {
+		return openBus
+	}
+	return m.ram[ramIndex(m.ram, int(m.ramBank), address-0xA000)]
+}
+
+func (m *mbc5) WriteRAM(address uint16, value uint8) {
+	if !m.ramEnabled || len(m.ram) == 0 {
+		return
+	}
+	m.ram[ramIndex(m.ram, int(m.ramBank), address-0xA000)] = value
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMBC5Banking(t *testing.T) {
+	cartridge, err := NewCartridge(newTestROM(0x1B, 0x04, 512))
+	assert.NoError(t, err)
+
+	cartridge.WriteROM(0x2000, 0x23)
+	cartridge.WriteROM(0x3000, 0x01)
+	assert.Equal(t, uint8(0x23), cartridge.ReadROM(0x4000))
+	assert.Equal(t, uint16(0x123), cartridge.(*mbc5).romBank)
+
+	// bank 0 can be mapped to 0x4000-0x7FFF
+	cartridge.WriteROM(0x3000, 0x00)
+	cartridge.WriteROM(0x2000, 0x00)
+	assert.Equal(t, uint8(0x00), cartridge.ReadROM(0x4000))
+
+	cartridge.WriteROM(0x0000, 0x0A)
+	cartridge.WriteROM(0x4000, 0x0F)
+	cartridge.WriteRAM(0xBFFF, 0x42)
+	assert.Equal(t, uint8(0x42), cartridge.(*mbc5).ram[16*ramBankSize-1])
+}
+
+func TestMBC5Rumble(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rumble.gb")
+	assert.NoError(t, os.WriteFile(path, newTestROM(0x1D, 0x03, 4), 0644))
+
+	gb := NewGameBoy()
+	assert.NoError(t, gb.LoadCartridge(path))
+	var events []bool
+	gb.OnRumble(func(on bool) {
+		events = append(events, on)
+	})
+
+	gb.bus.write(0x4000, 0b1010)
+	assert.True(t, gb.Rumble())
+	gb.bus.write(0x4000, 0b1011)
+	gb.bus.write(0x4000, 0b0011)
+	assert.False(t, gb.Rumble())
+	assert.Equal(t, []bool{true, false}, events)
+
+	// bit 3 doesn't select RAM banks on rumble cartridges
+	assert.Equal(t, uint8(0b011), gb.bus.cartridge.(*mbc5).ramBank)
+}
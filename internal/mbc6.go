@@ -0,0 +1,95 @@
+package internal
+
+const (
+	mbc6BankSize    = 0x2000
+	mbc6RAMBankSize = 0x1000
+	mbc6RAMSize     = 32 * 1024
+	mbc6FlashSize   = 1024 * 1024
+)
+
+// mbc6 is the MBC6 memory bank controller of Net de Get. The switchable ROM and RAM areas are split into two
+// independently banked halves, and each ROM half can map the 1 MiB flash instead of ROM. Flash commands are not
+// emulated, the flash reads as erased.
+// https://gbdev.io/pandocs/MBC6.html
+type mbc6 struct {
+	rom   []byte
+	ram   []byte
+	flash []byte
+
+	ramEnabled   bool
+	flashEnabled bool
+	romBanks     [2]uint8 // 8 KiB banks of 0x4000-0x5FFF and 0x6000-0x7FFF
+	flashMapped  [2]bool
+	ramBanks     [2]uint8 // 4 KiB banks of 0xA000-0xAFFF and 0xB000-0xBFFF
+}
+
+func newMBC6(rom []byte) *mbc6 {
+	m := &mbc6{rom: rom, ram: make([]byte, mbc6RAMSize), flash: make([]byte, mbc6FlashSize)}
+	for i := range m.flash {
+		m.flash[i] = 0xFF
+	}
+	return m
+}
+
+func (m *mbc6) saveRAM() []byte {
+	return m.ram
+}
+
+func (m *mbc6) ReadROM(address uint16) uint8 {
+	if address < 0x4000 {
+		return m.rom[address]
+	}
+
+	half := (address - 0x4000) / mbc6BankSize
+	offset := int(address % mbc6BankSize)
+	bank := int(m.romBanks[half])
+	if m.flashMapped[half] {
+		if !m.flashEnabled {
+			return openBus
+		}
+		return m.flash[(bank*mbc6BankSize+offset)%len(m.flash)]
+	}
+	return m.rom[(bank*mbc6BankSize+offset)%len(m.rom)]
+}
+
+func (m *mbc6) WriteROM(address uint16, value uint8) {
+	switch {
+	case address < 0x0400:
+		m.ramEnabled = value&0x0F == 0x0A
+	case address < 0x0800:
+		m.ramBanks[0] = value & 0b111
+	case address < 0x0C00:
+		m.ramBanks[1] = value & 0b111
+	case address < 0x1000:
+		m.flashEnabled = value&0b1 != 0
+	case address < 0x2000:
+		// flash write enable, flash writes are not emulated
+	case address < 0x2800:
+		m.romBanks[0] = value & 0x7F
+	case address < 0x3000:
+		m.flashMapped[0] = value == 0x08
+	case address < 0x3800:
+		m.romBanks[1] = value & 0x7F
+	case address < 0x4000:
+		m.flashMapped[1] = value == 0x08
+	}
+}
+
+func (m *mbc6) ramIndex(address uint16) int {
+	half := (address - 0xA000) / mbc6RAMBankSize
+	return (int(m.ramBanks[half])*mbc6RAMBankSize + int(address%mbc6RAMBankSize)) % len(m.ram)
+}
+
+func (m *mbc6) ReadRAM(address uint16) uint8 {
+	if !m.ramEnabled {
+		return openBus
+	}
+	return m.ram[m.ramIndex(address)]
+}
+
+func (m *mbc6) WriteRAM(address uint16, value uint8) {
+	if !m.ramEnabled {
+		return
+	}
+	m.ram[m.ramIndex(address)] = value
+}
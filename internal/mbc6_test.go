@@ -0,0 +1,33 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMBC6Banking(t *testing.T) {
+	rom := newTestROM(0x20, 0x00, 64)
+	for bank := 0; bank < len(rom)/mbc6BankSize; bank++ {
+		rom[bank*mbc6BankSize+1] = byte(bank)
+	}
+	cartridge, err := NewCartridge(rom)
+	assert.NoError(t, err)
+
+	cartridge.WriteROM(0x2000, 0x05)
+	cartridge.WriteROM(0x3000, 0x42)
+	assert.Equal(t, uint8(0x05), cartridge.ReadROM(0x4001))
+	assert.Equal(t, uint8(0x42), cartridge.ReadROM(0x6001))
+
+	// flash reads as erased when enabled
+	cartridge.WriteROM(0x2800, 0x08)
+	assert.Equal(t, openBus, cartridge.ReadROM(0x4001))
+
+	cartridge.WriteROM(0x0000, 0x0A)
+	cartridge.WriteROM(0x0400, 0x01)
+	cartridge.WriteROM(0x0800, 0x07)
+	cartridge.WriteRAM(0xA000, 0x12)
+	cartridge.WriteRAM(0xB000, 0x34)
+	ram := cartridge.(*mbc6).ram
+	assert.Equal(t, uint8(0x12), ram[1*mbc6RAMBankSize])
+	assert.Equal(t, uint8(0x34), ram[7*mbc6RAMBankSize])
+}
@@ -0,0 +1,112 @@
+package internal
+
+// Accelerometer values of MBC7 at rest and the change per g of acceleration.
+const (
+	accelerometerCenter = 0x81D0
+	accelerometerPerG   = 0x70
+)
+
+// mbc7 is the MBC7 memory bank controller with a two-axis accelerometer and a 256 byte EEPROM (Kirby Tilt 'n' Tumble,
+// Command Master). The accelerometer and EEPROM are mapped to 0xA000-0xAFFF instead of RAM. The rumble motor named by
+// cartridge type 0x22 is not used by any game and not emulated.
+// https://gbdev.io/pandocs/MBC7.html
+type mbc7 struct {
+	rom    []byte
+	eeprom *eeprom93LC56
+
+	ramEnabled1 bool // ramEnabled1 is set by writing 0x0A to 0x0000-0x1FFF
+	ramEnabled2 bool // ramEnabled2 is set by writing 0x40 to 0x4000-0x5FFF
+	romBank     uint8
+
+	tiltX, tiltY       float64 // tiltX and tiltY are the acceleration in g
+	latchedX, latchedY uint16
+	latchErased        bool // latchErased is set by writing 0x55 to Ax0x, a latch requires 0xAA to Ax1x afterward
+}
+
+func newMBC7(rom []byte) *mbc7 {
+	return &mbc7{rom: rom, eeprom: newEEPROM93LC56(), romBank: 1, latchedX: 0x8000, latchedY: 0x8000}
+}
+
+// setTilt sets the acceleration in g on the x and y axis, e.g. 1.0 when tilting the device by 90 degrees.
+func (m *mbc7) setTilt(x, y float64) {
+	m.tiltX, m.tiltY = x, y
+}
+
+func (m *mbc7) saveRAM() []byte {
+	return m.eeprom.data[:]
+}
+
+func (m *mbc7) mappedROMBank() int {
+	return wrapROMBank(m.rom, int(m.romBank))
+}
+
+func (m *mbc7) ReadROM(address uint16) uint8 {
+	if address < 0x4000 {
+		return romBank(m.rom, 0, address)
+	}
+	return romBank(m.rom, int(m.romBank), address-0x4000)
+}
+
+func (m *mbc7) WriteROM(address uint16, value uint8) {
+	switch {
+	case address < 0x2000:
+		m.ramEnabled1 = value == 0x0A
+		if !m.ramEnabled1 {
+			m.ramEnabled2 = false
+		}
+	case address < 0x4000:
+		m.romBank = value & 0x7F
+	case address < 0x6000:
+		m.ramEnabled2 = m.ramEnabled1 && value == 0x40
+	}
+}
+
+func (m *mbc7) enabled(address uint16) bool {
+	return m.ramEnabled1 && m.ramEnabled2 && address < 0xB000
+}
+
+func accelerometerValue(g float64) uint16 {
+	return uint16(accelerometerCenter + int(g*accelerometerPerG))
+}
+
+func (m *mbc7) ReadRAM(address uint16) uint8 {
+	if !m.enabled(address) {
+		return openBus
+	}
+	switch (address >> 4) & 0x0F {
+	case 0x2:
+		return lowPart(m.latchedX)
+	case 0x3:
+		return highPart(m.latchedX)
+	case 0x4:
+		return lowPart(m.latchedY)
+	case 0x5:
+		return highPart(m.latchedY)
+	case 0x6:
+		return 0x00
+	case 0x8:
+		return m.eeprom.read()
+	default:
+		return openBus
+	}
+}
+
+func (m *mbc7) WriteRAM(address uint16, value uint8) {
+	if !m.enabled(address) {
+		return
+	}
+	switch (address >> 4) & 0x0F {
+	case 0x0:
+		if value == 0x55 {
+			m.latchErased = true
+			m.latchedX, m.latchedY = 0x8000, 0x8000
+		}
+	case 0x1:
+		if value == 0xAA && m.latchErased {
+			m.latchErased = false
+			m.latchedX, m.latchedY = accelerometerValue(m.tiltX), accelerometerValue(m.tiltY)
+		}
+	case 0x8:
+		m.eeprom.write(value)
+	}
+}
@@ -0,0 +1,67 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func enableMBC7(cartridge Cartridge) {
+	cartridge.WriteROM(0x0000, 0x0A)
+	cartridge.WriteROM(0x4000, 0x40)
+}
+
+func TestMBC7Accelerometer(t *testing.T) {
+	cartridge, err := NewCartridge(newTestROM(0x22, 0x00, 4))
+	assert.NoError(t, err)
+
+	gb := NewGameBoy()
+	gb.bus.cartridge = cartridge
+	gb.SetTilt(1, -0.5)
+
+	// not mapped until both enable registers are written
+	assert.Equal(t, openBus, cartridge.ReadRAM(0xA020))
+	enableMBC7(cartridge)
+
+	cartridge.WriteRAM(0xA000, 0x55)
+	assert.Equal(t, uint8(0x00), cartridge.ReadRAM(0xA020))
+	assert.Equal(t, uint8(0x80), cartridge.ReadRAM(0xA030))
+
+	cartridge.WriteRAM(0xA010, 0xAA)
+	x := uint16(cartridge.ReadRAM(0xA030))<<8 | uint16(cartridge.ReadRAM(0xA020))
+	y := uint16(cartridge.ReadRAM(0xA050))<<8 | uint16(cartridge.ReadRAM(0xA040))
+	assert.Equal(t, uint16(accelerometerCenter+accelerometerPerG), x)
+	assert.Equal(t, uint16(accelerometerCenter-accelerometerPerG/2), y)
+}
+
+// eepromTransfer clocks the bits into the EEPROM and returns the bits read from DO.
+func eepromTransfer(cartridge Cartridge, bits string) string {
+	var out []byte
+	for _, bit := range bits {
+		di := uint8(0)
+		if bit == '1' {
+			di = 0b10
+		}
+		cartridge.WriteRAM(0xA080, 0x80|di)
+		cartridge.WriteRAM(0xA080, 0xC0|di)
+		out = append(out, '0'+cartridge.ReadRAM(0xA080)&0b1)
+	}
+	return string(out)
+}
+
+func TestMBC7EEPROM(t *testing.T) {
+	cartridge, _ := NewCartridge(newTestROM(0x22, 0x00, 4))
+	enableMBC7(cartridge)
+
+	// EWEN
+	eepromTransfer(cartridge, "100"+"11000000")
+	cartridge.WriteRAM(0xA080, 0x00)
+	// WRITE 0x1234 to word 5
+	eepromTransfer(cartridge, "101"+"00000101"+"0001001000110100")
+	cartridge.WriteRAM(0xA080, 0x00)
+	assert.Equal(t, uint8(0x12), cartridge.(*mbc7).eeprom.data[10])
+	assert.Equal(t, uint8(0x34), cartridge.(*mbc7).eeprom.data[11])
+
+	// READ word 5: a dummy zero followed by the data
+	out := eepromTransfer(cartridge, "110"+"00000101"+"0000000000000000")
+	assert.Equal(t, "0"+"0001001000110100", out[10:])
+}
@@ -0,0 +1,127 @@
+package internal
+
+// mmm01MenuSize is the size of the menu at the end of MMM01 multicarts, mapped to 0x0000-0x7FFF after reset.
+const mmm01MenuSize = 2 * romBankSize
+
+// isMMM01 reports whether the header of the menu at the end of the rom declares a MMM01 cartridge. The header at the
+// start of the rom belongs to the first game.
+func isMMM01(rom []byte) bool {
+	if len(rom) < 2*mmm01MenuSize {
+		return false
+	}
+	switch rom[len(rom)-mmm01MenuSize+cartridgeTypeAddress] {
+	case 0x0B, 0x0C, 0x0D:
+		return true
+	}
+	return false
+}
+
+// mmm01 is the MMM01 memory bank controller of multi-game cartridges. After reset the menu in the last 32 KiB of the
+// rom is mapped. The menu selects the outer ROM bank bits and a mask of the bank bits the game may change, then locks
+// the configuration by setting bit 6 of 0x0000-0x1FFF. From then on the selected game sees an MBC1-like controller.
+// https://gbdev.io/pandocs/MMM01.html
+type mmm01 struct {
+	rom []byte
+	ram []byte
+
+	locked      bool
+	ramEnabled  bool
+	romBankLow  uint8 // romBankLow holds bank bits 0-4 (0x2000-0x3FFF bits 0-4)
+	romBankMid  uint8 // romBankMid holds bank bits 5-6 (0x2000-0x3FFF bits 5-6), only writable while unlocked
+	romBankHigh uint8 // romBankHigh holds bank bits 7-8 (0x4000-0x5FFF bits 4-5), only writable while unlocked
+	romBankMask uint8 // romBankMask protects bank bits 1-4 from the game (0x6000-0x7FFF bits 2-5)
+	ramBank     uint8
+	ramBankHigh uint8 // ramBankHigh holds RAM bank bits 2-3 (0x4000-0x5FFF bits 2-3), only writable while unlocked
+	mode        uint8 // mode selects the MBC1 banking mode, RAM bank bits 0-1 are only used in mode 1
+}
+
+func newMMM01(rom, ram []byte) *mmm01 {
+	return &mmm01{rom: rom, ram: ram}
+}
+
+// writableBankBits returns the bits of romBankLow the game may change.
+func (m *mmm01) writableBankBits() uint8 {
+	return 0b1_1111 &^ (m.romBankMask << 1)
+}
+
+func (m *mmm01) romBankNumber() int {
+	return int(m.romBankHigh)<<7 | int(m.romBankMid)<<5 | int(m.romBankLow)
+}
+
+func (m *mmm01) saveRAM() []byte {
+	return m.ram
+}
+
+func (m *mmm01) mappedROMBank() int {
+	if !m.locked {
+		// the second bank of the menu
+		return len(m.rom)/romBankSize - 1
+	}
+	bank := m.romBankNumber()
+	if bank&int(m.writableBankBits()) == 0 {
+		bank |= 1
+	}
+	return wrapROMBank(m.rom, bank)
+}
+
+func (m *mmm01) ReadROM(address uint16) uint8 {
+	if !m.locked {
+		return m.rom[len(m.rom)-mmm01MenuSize+int(address)]
+	}
+	if address < 0x4000 {
+		// the first bank of the game
+		return romBank(m.rom, m.romBankNumber()&^int(m.writableBankBits()), address)
+	}
+	return romBank(m.rom, m.mappedROMBank(), address-0x4000)
+}
+
+func (m *mmm01) WriteROM(address uint16, value uint8) {
+	switch {
+	case address < 0x2000:
+		m.ramEnabled = value&0x0F == 0x0A
+		if !m.locked && value&0b0100_0000 != 0 {
+			m.locked = true
+		}
+	case address < 0x4000:
+		if m.locked {
+			writable := m.writableBankBits()
+			m.romBankLow = m.romBankLow&^writable | value&writable
+			return
+		}
+		m.romBankLow = value & 0b1_1111
+		m.romBankMid = (value >> 5) & 0b11
+	case address < 0x6000:
+		m.ramBank = value & 0b11
+		if !m.locked {
+			m.ramBankHigh = (value >> 2) & 0b11
+			m.romBankHigh = (value >> 4) & 0b11
+		}
+	default:
+		m.mode = value & 0b1
+		if !m.locked {
+			m.romBankMask = (value >> 2) & 0b1111
+		}
+	}
+}
+
+func (m *mmm01) ramIndex(address uint16) int {
+	bank := int(m.ramBankHigh) << 2
+	if m.mode == 1 {
+		bank |= int(m.ramBank)
+	}
+	return ramIndex(m.ram, bank, address-0xA000)
+}
+
+func (m *mmm01) ReadRAM(address uint16) uint8 {
+	if !m.ramEnabled || len(m.ram) == 0 {
+		return openBus
+	}
+	return m.ram[m.ramIndex(address)]
+}
+
+func (m *mmm01) WriteRAM(address uint16, value uint8) {
+	if !m.ramEnabled || len(m.ram) == 0 {
+		return
+	}
+	m.ram[m.ramIndex(address)] = value
+}
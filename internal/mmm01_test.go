@@ -0,0 +1,59 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMMM01(t *testing.T) {
+	rom := newTestROM(0x01, 0x00, 64)
+	// the menu header at the end of the rom
+	rom[len(rom)-mmm01MenuSize+cartridgeTypeAddress] = 0x0D
+	rom[len(rom)-mmm01MenuSize+ramSizeAddress] = 0x03
+
+	cartridge, err := NewCartridge(rom)
+	assert.NoError(t, err)
+	assert.IsType(t, &mmm01{}, cartridge)
+
+	// the menu is mapped after reset
+	assert.Equal(t, uint8(62), cartridge.ReadROM(0x0000))
+	assert.Equal(t, uint8(63), cartridge.ReadROM(0x4000))
+
+	// select the game starting at bank 0x28 with 8 banks and lock
+	cartridge.WriteROM(0x2000, 0x28)
+	cartridge.WriteROM(0x6000, 0b11_0000)
+	cartridge.WriteROM(0x0000, 0x40)
+
+	assert.Equal(t, uint8(0x28), cartridge.ReadROM(0x0000))
+	assert.Equal(t, uint8(0x29), cartridge.ReadROM(0x4000))
+	cartridge.WriteROM(0x2000, 0x1F)
+	assert.Equal(t, uint8(0x2F), cartridge.ReadROM(0x4000))
+
+	// the outer bank bits can't be changed anymore
+	cartridge.WriteROM(0x2000, 0x60)
+	assert.Equal(t, uint8(0x28), cartridge.ReadROM(0x0000))
+}
+
+func TestMMM01RAMBankingMode(t *testing.T) {
+	rom := newTestROM(0x01, 0x00, 64)
+	rom[len(rom)-mmm01MenuSize+cartridgeTypeAddress] = 0x0D
+	rom[len(rom)-mmm01MenuSize+ramSizeAddress] = 0x04
+	cartridge, err := NewCartridge(rom)
+	assert.NoError(t, err)
+
+	// select the outer RAM bank 4 and lock
+	cartridge.WriteROM(0x4000, 0b0100)
+	cartridge.WriteROM(0x0000, 0x4A)
+
+	cartridge.WriteRAM(0xA000, 0x40)
+	cartridge.WriteROM(0x4000, 0b11)
+	// in mode 0 the RAM bank bits are ignored like on the MBC1
+	assert.Equal(t, uint8(0x40), cartridge.ReadRAM(0xA000))
+
+	cartridge.WriteROM(0x6000, 0b1)
+	cartridge.WriteRAM(0xA000, 0x47)
+	cartridge.WriteROM(0x4000, 0b00)
+	assert.Equal(t, uint8(0x40), cartridge.ReadRAM(0xA000))
+	cartridge.WriteROM(0x4000, 0b11)
+	assert.Equal(t, uint8(0x47), cartridge.ReadRAM(0xA000))
+}
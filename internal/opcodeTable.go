@@ -0,0 +1,527 @@
+// Code generated by generateOpcodeTable from Opcodes.json; DO NOT EDIT.
+
+package internal
+
+// unprefixedInstructions is indexed by the opcode. The entry of the 0xCB prefix has no handler, the cpu
+// dispatches the following byte through prefixedInstructions instead.
+var unprefixedInstructions = [256]instruction{
+	0x00: {name: "NOP", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.nop(); return true }},
+	0x01: {name: "LD BC, n16", bytes: 3, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld16(bus, operandBC, operandImmediate16); return true }},
+	0x02: {name: "LD [BC], A", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectBC, operandA); return true }},
+	0x03: {name: "INC BC", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.inc16(operandBC); return true }},
+	0x04: {name: "INC B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.inc8(bus, operandB); return true }},
+	0x05: {name: "DEC B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.dec8(bus, operandB); return true }},
+	0x06: {name: "LD B, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandB, operandImmediate8); return true }},
+	0x07: {name: "RLCA", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b1110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandA, rotateLeftCircularImpl); return true }},
+	0x08: {name: "LD [a16], SP", bytes: 3, cycles: [2]int{20, 20}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld16(bus, operandIndirectWord16, operandSP); return true }},
+	0x09: {name: "ADD HL, BC", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.addHL(operandBC); return true }},
+	0x0A: {name: "LD A, [BC]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandIndirectBC); return true }},
+	0x0B: {name: "DEC BC", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.dec16(operandBC); return true }},
+	0x0C: {name: "INC C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.inc8(bus, operandC); return true }},
+	0x0D: {name: "DEC C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.dec8(bus, operandC); return true }},
+	0x0E: {name: "LD C, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandC, operandImmediate8); return true }},
+	0x0F: {name: "RRCA", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b1110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandA, rotateRightCircularImpl); return true }},
+	0x10: {name: "STOP n8", bytes: 2, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.stop(bus); return true }},
+	0x11: {name: "LD DE, n16", bytes: 3, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld16(bus, operandDE, operandImmediate16); return true }},
+	0x12: {name: "LD [DE], A", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectDE, operandA); return true }},
+	0x13: {name: "INC DE", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.inc16(operandDE); return true }},
+	0x14: {name: "INC D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.inc8(bus, operandD); return true }},
+	0x15: {name: "DEC D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.dec8(bus, operandD); return true }},
+	0x16: {name: "LD D, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandD, operandImmediate8); return true }},
+	0x17: {name: "RLA", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b1110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandA, rotateLeftImpl); return true }},
+	0x18: {name: "JR e8", bytes: 2, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.jr(bus, conditionAlways) }},
+	0x19: {name: "ADD HL, DE", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.addHL(operandDE); return true }},
+	0x1A: {name: "LD A, [DE]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandIndirectDE); return true }},
+	0x1B: {name: "DEC DE", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.dec16(operandDE); return true }},
+	0x1C: {name: "INC E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.inc8(bus, operandE); return true }},
+	0x1D: {name: "DEC E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.dec8(bus, operandE); return true }},
+	0x1E: {name: "LD E, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandE, operandImmediate8); return true }},
+	0x1F: {name: "RRA", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b1110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandA, rotateRightImpl); return true }},
+	0x20: {name: "JR NZ, e8", bytes: 2, cycles: [2]int{12, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.jr(bus, conditionNZ) }},
+	0x21: {name: "LD HL, n16", bytes: 3, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld16(bus, operandHL, operandImmediate16); return true }},
+	0x22: {name: "LD [HL+], A", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectHLIncrement, operandA); return true }},
+	0x23: {name: "INC HL", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.inc16(operandHL); return true }},
+	0x24: {name: "INC H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.inc8(bus, operandH); return true }},
+	0x25: {name: "DEC H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.dec8(bus, operandH); return true }},
+	0x26: {name: "LD H, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandH, operandImmediate8); return true }},
+	0x27: {name: "DAA", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0100_0000, reset: 0b0010_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.daa(); return true }},
+	0x28: {name: "JR Z, e8", bytes: 2, cycles: [2]int{12, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.jr(bus, conditionZ) }},
+	0x29: {name: "ADD HL, HL", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.addHL(operandHL); return true }},
+	0x2A: {name: "LD A, [HL+]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandIndirectHLIncrement); return true }},
+	0x2B: {name: "DEC HL", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.dec16(operandHL); return true }},
+	0x2C: {name: "INC L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.inc8(bus, operandL); return true }},
+	0x2D: {name: "DEC L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.dec8(bus, operandL); return true }},
+	0x2E: {name: "LD L, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandL, operandImmediate8); return true }},
+	0x2F: {name: "CPL", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1001_0000, reset: 0b0000_0000, set: 0b0110_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.cpl(); return true }},
+	0x30: {name: "JR NC, e8", bytes: 2, cycles: [2]int{12, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.jr(bus, conditionNC) }},
+	0x31: {name: "LD SP, n16", bytes: 3, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld16(bus, operandSP, operandImmediate16); return true }},
+	0x32: {name: "LD [HL-], A", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectHLDecrement, operandA); return true }},
+	0x33: {name: "INC SP", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.inc16(operandSP); return true }},
+	0x34: {name: "INC [HL]", bytes: 1, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.inc8(bus, operandIndirectHL); return true }},
+	0x35: {name: "DEC [HL]", bytes: 1, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.dec8(bus, operandIndirectHL); return true }},
+	0x36: {name: "LD [HL], n8", bytes: 2, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectHL, operandImmediate8); return true }},
+	0x37: {name: "SCF", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1000_0000, reset: 0b0110_0000, set: 0b0001_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.scf(); return true }},
+	0x38: {name: "JR C, e8", bytes: 2, cycles: [2]int{12, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.jr(bus, conditionC) }},
+	0x39: {name: "ADD HL, SP", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.addHL(operandSP); return true }},
+	0x3A: {name: "LD A, [HL-]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandIndirectHLDecrement); return true }},
+	0x3B: {name: "DEC SP", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.dec16(operandSP); return true }},
+	0x3C: {name: "INC A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.inc8(bus, operandA); return true }},
+	0x3D: {name: "DEC A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.dec8(bus, operandA); return true }},
+	0x3E: {name: "LD A, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandImmediate8); return true }},
+	0x3F: {name: "CCF", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ccf(); return true }},
+	0x40: {name: "LD B, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandB, operandB); return true }},
+	0x41: {name: "LD B, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandB, operandC); return true }},
+	0x42: {name: "LD B, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandB, operandD); return true }},
+	0x43: {name: "LD B, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandB, operandE); return true }},
+	0x44: {name: "LD B, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandB, operandH); return true }},
+	0x45: {name: "LD B, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandB, operandL); return true }},
+	0x46: {name: "LD B, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandB, operandIndirectHL); return true }},
+	0x47: {name: "LD B, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandB, operandA); return true }},
+	0x48: {name: "LD C, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandC, operandB); return true }},
+	0x49: {name: "LD C, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandC, operandC); return true }},
+	0x4A: {name: "LD C, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandC, operandD); return true }},
+	0x4B: {name: "LD C, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandC, operandE); return true }},
+	0x4C: {name: "LD C, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandC, operandH); return true }},
+	0x4D: {name: "LD C, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandC, operandL); return true }},
+	0x4E: {name: "LD C, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandC, operandIndirectHL); return true }},
+	0x4F: {name: "LD C, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandC, operandA); return true }},
+	0x50: {name: "LD D, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandD, operandB); return true }},
+	0x51: {name: "LD D, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandD, operandC); return true }},
+	0x52: {name: "LD D, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandD, operandD); return true }},
+	0x53: {name: "LD D, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandD, operandE); return true }},
+	0x54: {name: "LD D, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandD, operandH); return true }},
+	0x55: {name: "LD D, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandD, operandL); return true }},
+	0x56: {name: "LD D, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandD, operandIndirectHL); return true }},
+	0x57: {name: "LD D, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandD, operandA); return true }},
+	0x58: {name: "LD E, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandE, operandB); return true }},
+	0x59: {name: "LD E, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandE, operandC); return true }},
+	0x5A: {name: "LD E, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandE, operandD); return true }},
+	0x5B: {name: "LD E, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandE, operandE); return true }},
+	0x5C: {name: "LD E, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandE, operandH); return true }},
+	0x5D: {name: "LD E, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandE, operandL); return true }},
+	0x5E: {name: "LD E, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandE, operandIndirectHL); return true }},
+	0x5F: {name: "LD E, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandE, operandA); return true }},
+	0x60: {name: "LD H, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandH, operandB); return true }},
+	0x61: {name: "LD H, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandH, operandC); return true }},
+	0x62: {name: "LD H, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandH, operandD); return true }},
+	0x63: {name: "LD H, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandH, operandE); return true }},
+	0x64: {name: "LD H, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandH, operandH); return true }},
+	0x65: {name: "LD H, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandH, operandL); return true }},
+	0x66: {name: "LD H, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandH, operandIndirectHL); return true }},
+	0x67: {name: "LD H, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandH, operandA); return true }},
+	0x68: {name: "LD L, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandL, operandB); return true }},
+	0x69: {name: "LD L, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandL, operandC); return true }},
+	0x6A: {name: "LD L, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandL, operandD); return true }},
+	0x6B: {name: "LD L, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandL, operandE); return true }},
+	0x6C: {name: "LD L, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandL, operandH); return true }},
+	0x6D: {name: "LD L, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandL, operandL); return true }},
+	0x6E: {name: "LD L, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandL, operandIndirectHL); return true }},
+	0x6F: {name: "LD L, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandL, operandA); return true }},
+	0x70: {name: "LD [HL], B", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectHL, operandB); return true }},
+	0x71: {name: "LD [HL], C", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectHL, operandC); return true }},
+	0x72: {name: "LD [HL], D", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectHL, operandD); return true }},
+	0x73: {name: "LD [HL], E", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectHL, operandE); return true }},
+	0x74: {name: "LD [HL], H", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectHL, operandH); return true }},
+	0x75: {name: "LD [HL], L", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectHL, operandL); return true }},
+	0x76: {name: "HALT", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.halt(bus); return true }},
+	0x77: {name: "LD [HL], A", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectHL, operandA); return true }},
+	0x78: {name: "LD A, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandB); return true }},
+	0x79: {name: "LD A, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandC); return true }},
+	0x7A: {name: "LD A, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandD); return true }},
+	0x7B: {name: "LD A, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandE); return true }},
+	0x7C: {name: "LD A, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandH); return true }},
+	0x7D: {name: "LD A, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandL); return true }},
+	0x7E: {name: "LD A, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandIndirectHL); return true }},
+	0x7F: {name: "LD A, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandA); return true }},
+	0x80: {name: "ADD A, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.add(bus, operandB); return true }},
+	0x81: {name: "ADD A, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.add(bus, operandC); return true }},
+	0x82: {name: "ADD A, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.add(bus, operandD); return true }},
+	0x83: {name: "ADD A, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.add(bus, operandE); return true }},
+	0x84: {name: "ADD A, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.add(bus, operandH); return true }},
+	0x85: {name: "ADD A, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.add(bus, operandL); return true }},
+	0x86: {name: "ADD A, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.add(bus, operandIndirectHL); return true }},
+	0x87: {name: "ADD A, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.add(bus, operandA); return true }},
+	0x88: {name: "ADC A, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.adc(bus, operandB); return true }},
+	0x89: {name: "ADC A, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.adc(bus, operandC); return true }},
+	0x8A: {name: "ADC A, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.adc(bus, operandD); return true }},
+	0x8B: {name: "ADC A, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.adc(bus, operandE); return true }},
+	0x8C: {name: "ADC A, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.adc(bus, operandH); return true }},
+	0x8D: {name: "ADC A, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.adc(bus, operandL); return true }},
+	0x8E: {name: "ADC A, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.adc(bus, operandIndirectHL); return true }},
+	0x8F: {name: "ADC A, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.adc(bus, operandA); return true }},
+	0x90: {name: "SUB A, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sub(bus, operandB); return true }},
+	0x91: {name: "SUB A, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sub(bus, operandC); return true }},
+	0x92: {name: "SUB A, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sub(bus, operandD); return true }},
+	0x93: {name: "SUB A, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sub(bus, operandE); return true }},
+	0x94: {name: "SUB A, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sub(bus, operandH); return true }},
+	0x95: {name: "SUB A, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sub(bus, operandL); return true }},
+	0x96: {name: "SUB A, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sub(bus, operandIndirectHL); return true }},
+	0x97: {name: "SUB A, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0011_0000, set: 0b1100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sub(bus, operandA); return true }},
+	0x98: {name: "SBC A, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sbc(bus, operandB); return true }},
+	0x99: {name: "SBC A, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sbc(bus, operandC); return true }},
+	0x9A: {name: "SBC A, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sbc(bus, operandD); return true }},
+	0x9B: {name: "SBC A, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sbc(bus, operandE); return true }},
+	0x9C: {name: "SBC A, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sbc(bus, operandH); return true }},
+	0x9D: {name: "SBC A, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sbc(bus, operandL); return true }},
+	0x9E: {name: "SBC A, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sbc(bus, operandIndirectHL); return true }},
+	0x9F: {name: "SBC A, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sbc(bus, operandA); return true }},
+	0xA0: {name: "AND A, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0101_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.and(bus, operandB); return true }},
+	0xA1: {name: "AND A, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0101_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.and(bus, operandC); return true }},
+	0xA2: {name: "AND A, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0101_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.and(bus, operandD); return true }},
+	0xA3: {name: "AND A, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0101_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.and(bus, operandE); return true }},
+	0xA4: {name: "AND A, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0101_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.and(bus, operandH); return true }},
+	0xA5: {name: "AND A, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0101_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.and(bus, operandL); return true }},
+	0xA6: {name: "AND A, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0101_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.and(bus, operandIndirectHL); return true }},
+	0xA7: {name: "AND A, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0101_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.and(bus, operandA); return true }},
+	0xA8: {name: "XOR A, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.xor(bus, operandB); return true }},
+	0xA9: {name: "XOR A, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.xor(bus, operandC); return true }},
+	0xAA: {name: "XOR A, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.xor(bus, operandD); return true }},
+	0xAB: {name: "XOR A, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.xor(bus, operandE); return true }},
+	0xAC: {name: "XOR A, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.xor(bus, operandH); return true }},
+	0xAD: {name: "XOR A, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.xor(bus, operandL); return true }},
+	0xAE: {name: "XOR A, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.xor(bus, operandIndirectHL); return true }},
+	0xAF: {name: "XOR A, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b1000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.xor(bus, operandA); return true }},
+	0xB0: {name: "OR A, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.or(bus, operandB); return true }},
+	0xB1: {name: "OR A, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.or(bus, operandC); return true }},
+	0xB2: {name: "OR A, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.or(bus, operandD); return true }},
+	0xB3: {name: "OR A, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.or(bus, operandE); return true }},
+	0xB4: {name: "OR A, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.or(bus, operandH); return true }},
+	0xB5: {name: "OR A, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.or(bus, operandL); return true }},
+	0xB6: {name: "OR A, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.or(bus, operandIndirectHL); return true }},
+	0xB7: {name: "OR A, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.or(bus, operandA); return true }},
+	0xB8: {name: "CP A, B", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.cp(bus, operandB); return true }},
+	0xB9: {name: "CP A, C", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.cp(bus, operandC); return true }},
+	0xBA: {name: "CP A, D", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.cp(bus, operandD); return true }},
+	0xBB: {name: "CP A, E", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.cp(bus, operandE); return true }},
+	0xBC: {name: "CP A, H", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.cp(bus, operandH); return true }},
+	0xBD: {name: "CP A, L", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.cp(bus, operandL); return true }},
+	0xBE: {name: "CP A, [HL]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.cp(bus, operandIndirectHL); return true }},
+	0xBF: {name: "CP A, A", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0011_0000, set: 0b1100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.cp(bus, operandA); return true }},
+	0xC0: {name: "RET NZ", bytes: 1, cycles: [2]int{20, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.ret(bus, conditionNZ) }},
+	0xC1: {name: "POP BC", bytes: 1, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.pop16(bus, operandBC); return true }},
+	0xC2: {name: "JP NZ, a16", bytes: 3, cycles: [2]int{16, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.jp(bus, conditionNZ) }},
+	0xC3: {name: "JP a16", bytes: 3, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.jp(bus, conditionAlways) }},
+	0xC4: {name: "CALL NZ, a16", bytes: 3, cycles: [2]int{24, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.call(bus, conditionNZ) }},
+	0xC5: {name: "PUSH BC", bytes: 1, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.push16(bus, operandBC); return true }},
+	0xC6: {name: "ADD A, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.add(bus, operandImmediate8); return true }},
+	0xC7: {name: "RST $00", bytes: 1, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.rst(bus, 0x00); return true }},
+	0xC8: {name: "RET Z", bytes: 1, cycles: [2]int{20, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.ret(bus, conditionZ) }},
+	0xC9: {name: "RET", bytes: 1, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.ret(bus, conditionAlways) }},
+	0xCA: {name: "JP Z, a16", bytes: 3, cycles: [2]int{16, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.jp(bus, conditionZ) }},
+	0xCB: {name: "PREFIX", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: nil},
+	0xCC: {name: "CALL Z, a16", bytes: 3, cycles: [2]int{24, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.call(bus, conditionZ) }},
+	0xCD: {name: "CALL a16", bytes: 3, cycles: [2]int{24, 24}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.call(bus, conditionAlways) }},
+	0xCE: {name: "ADC A, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.adc(bus, operandImmediate8); return true }},
+	0xCF: {name: "RST $08", bytes: 1, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.rst(bus, 0x08); return true }},
+	0xD0: {name: "RET NC", bytes: 1, cycles: [2]int{20, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.ret(bus, conditionNC) }},
+	0xD1: {name: "POP DE", bytes: 1, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.pop16(bus, operandDE); return true }},
+	0xD2: {name: "JP NC, a16", bytes: 3, cycles: [2]int{16, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.jp(bus, conditionNC) }},
+	0xD3: {name: "ILLEGAL_D3", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.illegal(0xD3); return true }},
+	0xD4: {name: "CALL NC, a16", bytes: 3, cycles: [2]int{24, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.call(bus, conditionNC) }},
+	0xD5: {name: "PUSH DE", bytes: 1, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.push16(bus, operandDE); return true }},
+	0xD6: {name: "SUB A, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sub(bus, operandImmediate8); return true }},
+	0xD7: {name: "RST $10", bytes: 1, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.rst(bus, 0x10); return true }},
+	0xD8: {name: "RET C", bytes: 1, cycles: [2]int{20, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.ret(bus, conditionC) }},
+	0xD9: {name: "RETI", bytes: 1, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.reti(bus); return true }},
+	0xDA: {name: "JP C, a16", bytes: 3, cycles: [2]int{16, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.jp(bus, conditionC) }},
+	0xDB: {name: "ILLEGAL_DB", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.illegal(0xDB); return true }},
+	0xDC: {name: "CALL C, a16", bytes: 3, cycles: [2]int{24, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { return cpu.call(bus, conditionC) }},
+	0xDD: {name: "ILLEGAL_DD", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.illegal(0xDD); return true }},
+	0xDE: {name: "SBC A, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.sbc(bus, operandImmediate8); return true }},
+	0xDF: {name: "RST $18", bytes: 1, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.rst(bus, 0x18); return true }},
+	0xE0: {name: "LDH [a8], A", bytes: 2, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectHighImmediate8, operandA); return true }},
+	0xE1: {name: "POP HL", bytes: 1, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.pop16(bus, operandHL); return true }},
+	0xE2: {name: "LD [C], A", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectHighC, operandA); return true }},
+	0xE3: {name: "ILLEGAL_E3", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.illegal(0xE3); return true }},
+	0xE4: {name: "ILLEGAL_E4", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.illegal(0xE4); return true }},
+	0xE5: {name: "PUSH HL", bytes: 1, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.push16(bus, operandHL); return true }},
+	0xE6: {name: "AND A, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0101_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.and(bus, operandImmediate8); return true }},
+	0xE7: {name: "RST $20", bytes: 1, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.rst(bus, 0x20); return true }},
+	0xE8: {name: "ADD SP, e8", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b0000_0000, reset: 0b1100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.addStackPointer(bus); return true }},
+	0xE9: {name: "JP HL", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.jpHL(); return true }},
+	0xEA: {name: "LD [a16], A", bytes: 3, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandIndirectImmediate16, operandA); return true }},
+	0xEB: {name: "ILLEGAL_EB", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.illegal(0xEB); return true }},
+	0xEC: {name: "ILLEGAL_EC", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.illegal(0xEC); return true }},
+	0xED: {name: "ILLEGAL_ED", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.illegal(0xED); return true }},
+	0xEE: {name: "XOR A, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.xor(bus, operandImmediate8); return true }},
+	0xEF: {name: "RST $28", bytes: 1, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.rst(bus, 0x28); return true }},
+	0xF0: {name: "LDH A, [a8]", bytes: 2, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandIndirectHighImmediate8); return true }},
+	0xF1: {name: "POP AF", bytes: 1, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.pop16(bus, operandAF); return true }},
+	0xF2: {name: "LD A, [C]", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandIndirectHighC); return true }},
+	0xF3: {name: "DI", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.di(); return true }},
+	0xF4: {name: "ILLEGAL_F4", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.illegal(0xF4); return true }},
+	0xF5: {name: "PUSH AF", bytes: 1, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.push16(bus, operandAF); return true }},
+	0xF6: {name: "OR A, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.or(bus, operandImmediate8); return true }},
+	0xF7: {name: "RST $30", bytes: 1, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.rst(bus, 0x30); return true }},
+	0xF8: {name: "LD HL, SP+e8", bytes: 2, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b0000_0000, reset: 0b1100_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ldHLStackPointerOffset(bus); return true }},
+	0xF9: {name: "LD SP, HL", bytes: 1, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld16(bus, operandSP, operandHL); return true }},
+	0xFA: {name: "LD A, [a16]", bytes: 3, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ld8(bus, operandA, operandIndirectImmediate16); return true }},
+	0xFB: {name: "EI", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.ei(); return true }},
+	0xFC: {name: "ILLEGAL_FC", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.illegal(0xFC); return true }},
+	0xFD: {name: "ILLEGAL_FD", bytes: 1, cycles: [2]int{4, 4}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.illegal(0xFD); return true }},
+	0xFE: {name: "CP A, n8", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0000_0000, set: 0b0100_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.cp(bus, operandImmediate8); return true }},
+	0xFF: {name: "RST $38", bytes: 1, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.rst(bus, 0x38); return true }},
+}
+
+// prefixedInstructions is indexed by the byte following the 0xCB prefix.
+var prefixedInstructions = [256]instruction{
+	0x00: {name: "RLC B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandB, rotateLeftCircularImpl); return true }},
+	0x01: {name: "RLC C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandC, rotateLeftCircularImpl); return true }},
+	0x02: {name: "RLC D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandD, rotateLeftCircularImpl); return true }},
+	0x03: {name: "RLC E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandE, rotateLeftCircularImpl); return true }},
+	0x04: {name: "RLC H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandH, rotateLeftCircularImpl); return true }},
+	0x05: {name: "RLC L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandL, rotateLeftCircularImpl); return true }},
+	0x06: {name: "RLC [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandIndirectHL, rotateLeftCircularImpl); return true }},
+	0x07: {name: "RLC A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandA, rotateLeftCircularImpl); return true }},
+	0x08: {name: "RRC B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandB, rotateRightCircularImpl); return true }},
+	0x09: {name: "RRC C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandC, rotateRightCircularImpl); return true }},
+	0x0A: {name: "RRC D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandD, rotateRightCircularImpl); return true }},
+	0x0B: {name: "RRC E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandE, rotateRightCircularImpl); return true }},
+	0x0C: {name: "RRC H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandH, rotateRightCircularImpl); return true }},
+	0x0D: {name: "RRC L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandL, rotateRightCircularImpl); return true }},
+	0x0E: {name: "RRC [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandIndirectHL, rotateRightCircularImpl); return true }},
+	0x0F: {name: "RRC A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandA, rotateRightCircularImpl); return true }},
+	0x10: {name: "RL B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandB, rotateLeftImpl); return true }},
+	0x11: {name: "RL C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandC, rotateLeftImpl); return true }},
+	0x12: {name: "RL D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandD, rotateLeftImpl); return true }},
+	0x13: {name: "RL E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandE, rotateLeftImpl); return true }},
+	0x14: {name: "RL H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandH, rotateLeftImpl); return true }},
+	0x15: {name: "RL L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandL, rotateLeftImpl); return true }},
+	0x16: {name: "RL [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandIndirectHL, rotateLeftImpl); return true }},
+	0x17: {name: "RL A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandA, rotateLeftImpl); return true }},
+	0x18: {name: "RR B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandB, rotateRightImpl); return true }},
+	0x19: {name: "RR C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandC, rotateRightImpl); return true }},
+	0x1A: {name: "RR D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandD, rotateRightImpl); return true }},
+	0x1B: {name: "RR E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandE, rotateRightImpl); return true }},
+	0x1C: {name: "RR H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandH, rotateRightImpl); return true }},
+	0x1D: {name: "RR L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandL, rotateRightImpl); return true }},
+	0x1E: {name: "RR [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandIndirectHL, rotateRightImpl); return true }},
+	0x1F: {name: "RR A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandA, rotateRightImpl); return true }},
+	0x20: {name: "SLA B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandB, shiftLeftArithmeticImpl); return true }},
+	0x21: {name: "SLA C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandC, shiftLeftArithmeticImpl); return true }},
+	0x22: {name: "SLA D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandD, shiftLeftArithmeticImpl); return true }},
+	0x23: {name: "SLA E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandE, shiftLeftArithmeticImpl); return true }},
+	0x24: {name: "SLA H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandH, shiftLeftArithmeticImpl); return true }},
+	0x25: {name: "SLA L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandL, shiftLeftArithmeticImpl); return true }},
+	0x26: {name: "SLA [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandIndirectHL, shiftLeftArithmeticImpl); return true }},
+	0x27: {name: "SLA A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandA, shiftLeftArithmeticImpl); return true }},
+	0x28: {name: "SRA B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandB, shiftRightArithmeticImpl); return true }},
+	0x29: {name: "SRA C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandC, shiftRightArithmeticImpl); return true }},
+	0x2A: {name: "SRA D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandD, shiftRightArithmeticImpl); return true }},
+	0x2B: {name: "SRA E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandE, shiftRightArithmeticImpl); return true }},
+	0x2C: {name: "SRA H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandH, shiftRightArithmeticImpl); return true }},
+	0x2D: {name: "SRA L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandL, shiftRightArithmeticImpl); return true }},
+	0x2E: {name: "SRA [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool {
+		cpu.shift(bus, operandIndirectHL, shiftRightArithmeticImpl)
+		return true
+	}},
+	0x2F: {name: "SRA A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandA, shiftRightArithmeticImpl); return true }},
+	0x30: {name: "SWAP B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandB, swapImpl); return true }},
+	0x31: {name: "SWAP C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandC, swapImpl); return true }},
+	0x32: {name: "SWAP D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandD, swapImpl); return true }},
+	0x33: {name: "SWAP E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandE, swapImpl); return true }},
+	0x34: {name: "SWAP H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandH, swapImpl); return true }},
+	0x35: {name: "SWAP L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandL, swapImpl); return true }},
+	0x36: {name: "SWAP [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandIndirectHL, swapImpl); return true }},
+	0x37: {name: "SWAP A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0111_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandA, swapImpl); return true }},
+	0x38: {name: "SRL B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandB, shiftRightLogicalImpl); return true }},
+	0x39: {name: "SRL C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandC, shiftRightLogicalImpl); return true }},
+	0x3A: {name: "SRL D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandD, shiftRightLogicalImpl); return true }},
+	0x3B: {name: "SRL E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandE, shiftRightLogicalImpl); return true }},
+	0x3C: {name: "SRL H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandH, shiftRightLogicalImpl); return true }},
+	0x3D: {name: "SRL L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandL, shiftRightLogicalImpl); return true }},
+	0x3E: {name: "SRL [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandIndirectHL, shiftRightLogicalImpl); return true }},
+	0x3F: {name: "SRL A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0000_0000, reset: 0b0110_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.shift(bus, operandA, shiftRightLogicalImpl); return true }},
+	0x40: {name: "BIT 0, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 0, operandB); return true }},
+	0x41: {name: "BIT 0, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 0, operandC); return true }},
+	0x42: {name: "BIT 0, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 0, operandD); return true }},
+	0x43: {name: "BIT 0, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 0, operandE); return true }},
+	0x44: {name: "BIT 0, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 0, operandH); return true }},
+	0x45: {name: "BIT 0, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 0, operandL); return true }},
+	0x46: {name: "BIT 0, [HL]", bytes: 2, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 0, operandIndirectHL); return true }},
+	0x47: {name: "BIT 0, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 0, operandA); return true }},
+	0x48: {name: "BIT 1, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 1, operandB); return true }},
+	0x49: {name: "BIT 1, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 1, operandC); return true }},
+	0x4A: {name: "BIT 1, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 1, operandD); return true }},
+	0x4B: {name: "BIT 1, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 1, operandE); return true }},
+	0x4C: {name: "BIT 1, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 1, operandH); return true }},
+	0x4D: {name: "BIT 1, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 1, operandL); return true }},
+	0x4E: {name: "BIT 1, [HL]", bytes: 2, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 1, operandIndirectHL); return true }},
+	0x4F: {name: "BIT 1, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 1, operandA); return true }},
+	0x50: {name: "BIT 2, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 2, operandB); return true }},
+	0x51: {name: "BIT 2, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 2, operandC); return true }},
+	0x52: {name: "BIT 2, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 2, operandD); return true }},
+	0x53: {name: "BIT 2, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 2, operandE); return true }},
+	0x54: {name: "BIT 2, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 2, operandH); return true }},
+	0x55: {name: "BIT 2, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 2, operandL); return true }},
+	0x56: {name: "BIT 2, [HL]", bytes: 2, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 2, operandIndirectHL); return true }},
+	0x57: {name: "BIT 2, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 2, operandA); return true }},
+	0x58: {name: "BIT 3, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 3, operandB); return true }},
+	0x59: {name: "BIT 3, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 3, operandC); return true }},
+	0x5A: {name: "BIT 3, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 3, operandD); return true }},
+	0x5B: {name: "BIT 3, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 3, operandE); return true }},
+	0x5C: {name: "BIT 3, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 3, operandH); return true }},
+	0x5D: {name: "BIT 3, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 3, operandL); return true }},
+	0x5E: {name: "BIT 3, [HL]", bytes: 2, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 3, operandIndirectHL); return true }},
+	0x5F: {name: "BIT 3, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 3, operandA); return true }},
+	0x60: {name: "BIT 4, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 4, operandB); return true }},
+	0x61: {name: "BIT 4, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 4, operandC); return true }},
+	0x62: {name: "BIT 4, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 4, operandD); return true }},
+	0x63: {name: "BIT 4, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 4, operandE); return true }},
+	0x64: {name: "BIT 4, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 4, operandH); return true }},
+	0x65: {name: "BIT 4, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 4, operandL); return true }},
+	0x66: {name: "BIT 4, [HL]", bytes: 2, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 4, operandIndirectHL); return true }},
+	0x67: {name: "BIT 4, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 4, operandA); return true }},
+	0x68: {name: "BIT 5, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 5, operandB); return true }},
+	0x69: {name: "BIT 5, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 5, operandC); return true }},
+	0x6A: {name: "BIT 5, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 5, operandD); return true }},
+	0x6B: {name: "BIT 5, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 5, operandE); return true }},
+	0x6C: {name: "BIT 5, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 5, operandH); return true }},
+	0x6D: {name: "BIT 5, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 5, operandL); return true }},
+	0x6E: {name: "BIT 5, [HL]", bytes: 2, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 5, operandIndirectHL); return true }},
+	0x6F: {name: "BIT 5, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 5, operandA); return true }},
+	0x70: {name: "BIT 6, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 6, operandB); return true }},
+	0x71: {name: "BIT 6, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 6, operandC); return true }},
+	0x72: {name: "BIT 6, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 6, operandD); return true }},
+	0x73: {name: "BIT 6, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 6, operandE); return true }},
+	0x74: {name: "BIT 6, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 6, operandH); return true }},
+	0x75: {name: "BIT 6, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 6, operandL); return true }},
+	0x76: {name: "BIT 6, [HL]", bytes: 2, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 6, operandIndirectHL); return true }},
+	0x77: {name: "BIT 6, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 6, operandA); return true }},
+	0x78: {name: "BIT 7, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 7, operandB); return true }},
+	0x79: {name: "BIT 7, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 7, operandC); return true }},
+	0x7A: {name: "BIT 7, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 7, operandD); return true }},
+	0x7B: {name: "BIT 7, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 7, operandE); return true }},
+	0x7C: {name: "BIT 7, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 7, operandH); return true }},
+	0x7D: {name: "BIT 7, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 7, operandL); return true }},
+	0x7E: {name: "BIT 7, [HL]", bytes: 2, cycles: [2]int{12, 12}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 7, operandIndirectHL); return true }},
+	0x7F: {name: "BIT 7, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b0001_0000, reset: 0b0100_0000, set: 0b0010_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.bit(bus, 7, operandA); return true }},
+	0x80: {name: "RES 0, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 0, operandB); return true }},
+	0x81: {name: "RES 0, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 0, operandC); return true }},
+	0x82: {name: "RES 0, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 0, operandD); return true }},
+	0x83: {name: "RES 0, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 0, operandE); return true }},
+	0x84: {name: "RES 0, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 0, operandH); return true }},
+	0x85: {name: "RES 0, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 0, operandL); return true }},
+	0x86: {name: "RES 0, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 0, operandIndirectHL); return true }},
+	0x87: {name: "RES 0, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 0, operandA); return true }},
+	0x88: {name: "RES 1, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 1, operandB); return true }},
+	0x89: {name: "RES 1, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 1, operandC); return true }},
+	0x8A: {name: "RES 1, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 1, operandD); return true }},
+	0x8B: {name: "RES 1, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 1, operandE); return true }},
+	0x8C: {name: "RES 1, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 1, operandH); return true }},
+	0x8D: {name: "RES 1, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 1, operandL); return true }},
+	0x8E: {name: "RES 1, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 1, operandIndirectHL); return true }},
+	0x8F: {name: "RES 1, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 1, operandA); return true }},
+	0x90: {name: "RES 2, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 2, operandB); return true }},
+	0x91: {name: "RES 2, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 2, operandC); return true }},
+	0x92: {name: "RES 2, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 2, operandD); return true }},
+	0x93: {name: "RES 2, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 2, operandE); return true }},
+	0x94: {name: "RES 2, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 2, operandH); return true }},
+	0x95: {name: "RES 2, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 2, operandL); return true }},
+	0x96: {name: "RES 2, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 2, operandIndirectHL); return true }},
+	0x97: {name: "RES 2, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 2, operandA); return true }},
+	0x98: {name: "RES 3, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 3, operandB); return true }},
+	0x99: {name: "RES 3, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 3, operandC); return true }},
+	0x9A: {name: "RES 3, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 3, operandD); return true }},
+	0x9B: {name: "RES 3, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 3, operandE); return true }},
+	0x9C: {name: "RES 3, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 3, operandH); return true }},
+	0x9D: {name: "RES 3, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 3, operandL); return true }},
+	0x9E: {name: "RES 3, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 3, operandIndirectHL); return true }},
+	0x9F: {name: "RES 3, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 3, operandA); return true }},
+	0xA0: {name: "RES 4, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 4, operandB); return true }},
+	0xA1: {name: "RES 4, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 4, operandC); return true }},
+	0xA2: {name: "RES 4, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 4, operandD); return true }},
+	0xA3: {name: "RES 4, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 4, operandE); return true }},
+	0xA4: {name: "RES 4, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 4, operandH); return true }},
+	0xA5: {name: "RES 4, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 4, operandL); return true }},
+	0xA6: {name: "RES 4, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 4, operandIndirectHL); return true }},
+	0xA7: {name: "RES 4, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 4, operandA); return true }},
+	0xA8: {name: "RES 5, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 5, operandB); return true }},
+	0xA9: {name: "RES 5, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 5, operandC); return true }},
+	0xAA: {name: "RES 5, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 5, operandD); return true }},
+	0xAB: {name: "RES 5, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 5, operandE); return true }},
+	0xAC: {name: "RES 5, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 5, operandH); return true }},
+	0xAD: {name: "RES 5, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 5, operandL); return true }},
+	0xAE: {name: "RES 5, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 5, operandIndirectHL); return true }},
+	0xAF: {name: "RES 5, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 5, operandA); return true }},
+	0xB0: {name: "RES 6, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 6, operandB); return true }},
+	0xB1: {name: "RES 6, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 6, operandC); return true }},
+	0xB2: {name: "RES 6, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 6, operandD); return true }},
+	0xB3: {name: "RES 6, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 6, operandE); return true }},
+	0xB4: {name: "RES 6, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 6, operandH); return true }},
+	0xB5: {name: "RES 6, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 6, operandL); return true }},
+	0xB6: {name: "RES 6, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 6, operandIndirectHL); return true }},
+	0xB7: {name: "RES 6, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 6, operandA); return true }},
+	0xB8: {name: "RES 7, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 7, operandB); return true }},
+	0xB9: {name: "RES 7, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 7, operandC); return true }},
+	0xBA: {name: "RES 7, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 7, operandD); return true }},
+	0xBB: {name: "RES 7, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 7, operandE); return true }},
+	0xBC: {name: "RES 7, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 7, operandH); return true }},
+	0xBD: {name: "RES 7, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 7, operandL); return true }},
+	0xBE: {name: "RES 7, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 7, operandIndirectHL); return true }},
+	0xBF: {name: "RES 7, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.res(bus, 7, operandA); return true }},
+	0xC0: {name: "SET 0, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 0, operandB); return true }},
+	0xC1: {name: "SET 0, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 0, operandC); return true }},
+	0xC2: {name: "SET 0, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 0, operandD); return true }},
+	0xC3: {name: "SET 0, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 0, operandE); return true }},
+	0xC4: {name: "SET 0, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 0, operandH); return true }},
+	0xC5: {name: "SET 0, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 0, operandL); return true }},
+	0xC6: {name: "SET 0, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 0, operandIndirectHL); return true }},
+	0xC7: {name: "SET 0, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 0, operandA); return true }},
+	0xC8: {name: "SET 1, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 1, operandB); return true }},
+	0xC9: {name: "SET 1, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 1, operandC); return true }},
+	0xCA: {name: "SET 1, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 1, operandD); return true }},
+	0xCB: {name: "SET 1, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 1, operandE); return true }},
+	0xCC: {name: "SET 1, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 1, operandH); return true }},
+	0xCD: {name: "SET 1, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 1, operandL); return true }},
+	0xCE: {name: "SET 1, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 1, operandIndirectHL); return true }},
+	0xCF: {name: "SET 1, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 1, operandA); return true }},
+	0xD0: {name: "SET 2, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 2, operandB); return true }},
+	0xD1: {name: "SET 2, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 2, operandC); return true }},
+	0xD2: {name: "SET 2, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 2, operandD); return true }},
+	0xD3: {name: "SET 2, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 2, operandE); return true }},
+	0xD4: {name: "SET 2, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 2, operandH); return true }},
+	0xD5: {name: "SET 2, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 2, operandL); return true }},
+	0xD6: {name: "SET 2, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 2, operandIndirectHL); return true }},
+	0xD7: {name: "SET 2, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 2, operandA); return true }},
+	0xD8: {name: "SET 3, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 3, operandB); return true }},
+	0xD9: {name: "SET 3, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 3, operandC); return true }},
+	0xDA: {name: "SET 3, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 3, operandD); return true }},
+	0xDB: {name: "SET 3, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 3, operandE); return true }},
+	0xDC: {name: "SET 3, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 3, operandH); return true }},
+	0xDD: {name: "SET 3, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 3, operandL); return true }},
+	0xDE: {name: "SET 3, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 3, operandIndirectHL); return true }},
+	0xDF: {name: "SET 3, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 3, operandA); return true }},
+	0xE0: {name: "SET 4, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 4, operandB); return true }},
+	0xE1: {name: "SET 4, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 4, operandC); return true }},
+	0xE2: {name: "SET 4, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 4, operandD); return true }},
+	0xE3: {name: "SET 4, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 4, operandE); return true }},
+	0xE4: {name: "SET 4, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 4, operandH); return true }},
+	0xE5: {name: "SET 4, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 4, operandL); return true }},
+	0xE6: {name: "SET 4, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 4, operandIndirectHL); return true }},
+	0xE7: {name: "SET 4, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 4, operandA); return true }},
+	0xE8: {name: "SET 5, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 5, operandB); return true }},
+	0xE9: {name: "SET 5, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 5, operandC); return true }},
+	0xEA: {name: "SET 5, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 5, operandD); return true }},
+	0xEB: {name: "SET 5, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 5, operandE); return true }},
+	0xEC: {name: "SET 5, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 5, operandH); return true }},
+	0xED: {name: "SET 5, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 5, operandL); return true }},
+	0xEE: {name: "SET 5, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 5, operandIndirectHL); return true }},
+	0xEF: {name: "SET 5, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 5, operandA); return true }},
+	0xF0: {name: "SET 6, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 6, operandB); return true }},
+	0xF1: {name: "SET 6, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 6, operandC); return true }},
+	0xF2: {name: "SET 6, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 6, operandD); return true }},
+	0xF3: {name: "SET 6, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 6, operandE); return true }},
+	0xF4: {name: "SET 6, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 6, operandH); return true }},
+	0xF5: {name: "SET 6, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 6, operandL); return true }},
+	0xF6: {name: "SET 6, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 6, operandIndirectHL); return true }},
+	0xF7: {name: "SET 6, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 6, operandA); return true }},
+	0xF8: {name: "SET 7, B", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 7, operandB); return true }},
+	0xF9: {name: "SET 7, C", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 7, operandC); return true }},
+	0xFA: {name: "SET 7, D", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 7, operandD); return true }},
+	0xFB: {name: "SET 7, E", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 7, operandE); return true }},
+	0xFC: {name: "SET 7, H", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 7, operandH); return true }},
+	0xFD: {name: "SET 7, L", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 7, operandL); return true }},
+	0xFE: {name: "SET 7, [HL]", bytes: 2, cycles: [2]int{16, 16}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 7, operandIndirectHL); return true }},
+	0xFF: {name: "SET 7, A", bytes: 2, cycles: [2]int{8, 8}, flags: flagEffects{keep: 0b1111_0000, reset: 0b0000_0000, set: 0b0000_0000}, exec: func(cpu *cpu, bus *Bus) bool { cpu.set(bus, 7, operandA); return true }},
+}
@@ -0,0 +1,146 @@
+// Package opcodes decodes the opcode table of the SM83 cpu, Opcodes.json. It doesn't depend on the generated opcode
+// table of the cpu, so the generator building that table can use it even while the table is out of date.
+package opcodes
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// opcodesJSON is the opcode table from https://gbdev.io/gb-opcodes/Opcodes.json.
+//
+//go:embed Opcodes.json
+var opcodesJSON []byte
+
+type Operand struct {
+	Name      string `json:"name"`
+	Bytes     int    `json:"bytes,omitempty"`
+	Immediate bool   `json:"immediate"`
+	Increment bool   `json:"increment,omitempty"`
+	Decrement bool   `json:"decrement,omitempty"`
+}
+
+type Flags struct {
+	Z string `json:"Z"`
+	N string `json:"N"`
+	H string `json:"H"`
+	C string `json:"C"`
+}
+
+type Opcode struct {
+	Mnemonic  string    `json:"mnemonic"`
+	Bytes     int       `json:"bytes"`
+	Cycles    []int     `json:"cycles"`
+	Operands  []Operand `json:"operands"`
+	Immediate bool      `json:"immediate"`
+	Flags     Flags     `json:"flags"`
+}
+
+type ByteKey struct {
+	Value byte
+}
+
+func (k *ByteKey) UnmarshalJSON(bytes []byte) error {
+	return k.UnmarshalText(bytes)
+}
+
+func RemoveQuotes(text string) string {
+	if len(text) > 0 && text[0] == '"' {
+		text = text[1:]
+	}
+	if len(text) > 0 && text[len(text)-1] == '"' {
+		text = text[:len(text)-1]
+	}
+	return text
+}
+
+func RemoveHexPrefix(text string) string {
+	if strings.HasPrefix(text, "0x") {
+		return text[2:]
+	}
+
+	return text
+}
+
+func (k *ByteKey) UnmarshalText(bytes []byte) error {
+	text := string(bytes)
+
+	text = RemoveQuotes(text)
+	text = RemoveHexPrefix(text)
+
+	decodeString, err := hex.DecodeString(text)
+	if err != nil {
+		return err
+	}
+
+	if len(decodeString) != 1 {
+		return errors.New("invalid byte key")
+	}
+
+	*k = ByteKey{decodeString[0]}
+	return nil
+}
+
+type OpcodeList struct {
+	UnPrefixed map[ByteKey]Opcode `json:"unprefixed"`
+
+	/// CbPrefixed represent all instructions following a 0xCB prefix
+	CbPrefixed map[ByteKey]Opcode `json:"cbprefixed"`
+}
+
+// defaultOpcodes parses the embedded opcode table on first use. The cached list is shared and never handed out.
+var defaultOpcodes = sync.OnceValues(func() (*OpcodeList, error) {
+	return Decode(bytes.NewReader(opcodesJSON))
+})
+
+// Parse returns the opcode table embedded in the binary. The table is parsed once, but every call returns a deep
+// copy of all 512 opcodes that may be modified freely, so callers should call it once and pass the table on.
+func Parse() (*OpcodeList, error) {
+	list, err := defaultOpcodes()
+	if err != nil {
+		return nil, err
+	}
+	return list.clone(), nil
+}
+
+// Load reads an alternate opcode table in the format of Opcodes.json from disk.
+func Load(path string) (*OpcodeList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return Decode(file)
+}
+
+// Decode reads an opcode table in the format of Opcodes.json.
+func Decode(r io.Reader) (*OpcodeList, error) {
+	list := &OpcodeList{}
+	err := json.NewDecoder(r).Decode(&list)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func cloneOpcodes(opcodes map[ByteKey]Opcode) map[ByteKey]Opcode {
+	clone := make(map[ByteKey]Opcode, len(opcodes))
+	for key, opcode := range opcodes {
+		opcode.Cycles = slices.Clone(opcode.Cycles)
+		opcode.Operands = slices.Clone(opcode.Operands)
+		clone[key] = opcode
+	}
+	return clone
+}
+
+func (l *OpcodeList) clone() *OpcodeList {
+	return &OpcodeList{UnPrefixed: cloneOpcodes(l.UnPrefixed), CbPrefixed: cloneOpcodes(l.CbPrefixed)}
+}
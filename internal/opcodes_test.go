@@ -23,5 +23,26 @@ func TestParseOpcodes(t *testing.T) {
 		},
 	}
 
-	assert.Equal(t, unprefixed_0x00, codes.UnPrefixed[ByteKey{0x00}])
+	assert.Equal(t, unprefixed_0x00, codes.UnPrefixed[ByteKey{Value: 0x00}])
+}
+
+func TestParseOpcodesReturnsCopy(t *testing.T) {
+	codes, err := ParseOpcodes()
+	assert.NoError(t, err)
+	codes.UnPrefixed[ByteKey{Value: 0x00}] = Opcode{Mnemonic: "CHANGED"}
+	codes.CbPrefixed[ByteKey{Value: 0x37}].Operands[0].Name = "CHANGED"
+
+	codes, err = ParseOpcodes()
+	assert.NoError(t, err)
+	assert.Equal(t, "NOP", codes.UnPrefixed[ByteKey{Value: 0x00}].Mnemonic)
+	assert.Equal(t, "A", codes.CbPrefixed[ByteKey{Value: 0x37}].Operands[0].Name)
+}
+
+func TestLoadOpcodes(t *testing.T) {
+	codes, err := LoadOpcodes("opcodes/Opcodes.json")
+	assert.NoError(t, err)
+	assert.Equal(t, "SWAP", codes.CbPrefixed[ByteKey{Value: 0x37}].Mnemonic)
+
+	_, err = LoadOpcodes("missing.json")
+	assert.Error(t, err)
 }
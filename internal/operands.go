@@ -0,0 +1,205 @@
+package internal
+
+import "log"
+
+// operand8 identifies the source or destination of an 8-bit instruction. The first eight values follow the register
+// encoding used in the lowest three bits of many opcodes (B, C, D, E, H, L, [HL], A).
+type operand8 uint8
+
+const (
+	operandB operand8 = iota
+	operandC
+	operandD
+	operandE
+	operandH
+	operandL
+	operandIndirectHL
+	operandA
+	operandIndirectBC
+	operandIndirectDE
+	// operandIndirectHLIncrement is [HL+]: HL is incremented after the access
+	operandIndirectHLIncrement
+	// operandIndirectHLDecrement is [HL-]: HL is decremented after the access
+	operandIndirectHLDecrement
+	// operandIndirectHighC is the address 0xFF00 + C
+	operandIndirectHighC
+	// operandIndirectHighImmediate8 is the address 0xFF00 + n8, with n8 read from the instruction stream
+	operandIndirectHighImmediate8
+	// operandIndirectImmediate16 is the address a16 read from the instruction stream
+	operandIndirectImmediate16
+	// operandImmediate8 is n8 read from the instruction stream
+	operandImmediate8
+)
+
+// operand16 identifies the source or destination of a 16-bit instruction.
+type operand16 uint8
+
+const (
+	operandBC operand16 = iota
+	operandDE
+	operandHL
+	operandSP
+	operandAF
+	// operandImmediate16 is n16 read from the instruction stream
+	operandImmediate16
+	// operandIndirectWord16 is the 16-bit little-endian word at the address a16 read from the instruction stream
+	operandIndirectWord16
+)
+
+// condition of a conditional jump, call or return.
+type condition uint8
+
+const (
+	conditionAlways condition = iota
+	conditionNZ
+	conditionZ
+	conditionNC
+	conditionC
+)
+
+func (cpu *cpu) conditionMet(cc condition) bool {
+	flags := cpu.registers.flags()
+	switch cc {
+	case conditionNZ:
+		return !flags.z()
+	case conditionZ:
+		return flags.z()
+	case conditionNC:
+		return !flags.c()
+	case conditionC:
+		return flags.c()
+	default:
+		return true
+	}
+}
+
+// register8Ptr returns the register behind the operand or nil if the operand is not a register.
+func (r *registers) register8Ptr(operand operand8) *uint8 {
+	switch operand {
+	case operandA:
+		return r.aPtr()
+	case operandB:
+		return r.bPtr()
+	case operandC:
+		return r.cPtr()
+	case operandD:
+		return r.dPtr()
+	case operandE:
+		return r.ePtr()
+	case operandH:
+		return r.hPtr()
+	case operandL:
+		return r.lPtr()
+	default:
+		return nil
+	}
+}
+
+// address8 resolves the memory address of an indirect operand. Immediate address parts are read from the instruction
+// stream and HL is adjusted for [HL+] and [HL-].
+func (cpu *cpu) address8(bus *Bus, operand operand8) uint16 {
+	const highPage = uint8(0xFF)
+
+	switch operand {
+	case operandIndirectHL:
+		return cpu.registers.hl
+	case operandIndirectBC:
+		return cpu.registers.bc
+	case operandIndirectDE:
+		return cpu.registers.de
+	case operandIndirectHLIncrement:
+		address := cpu.registers.hl
+		cpu.registers.hl++
+		return address
+	case operandIndirectHLDecrement:
+		address := cpu.registers.hl
+		cpu.registers.hl--
+		return address
+	case operandIndirectHighC:
+		return unsigned16(cpu.registers.c(), highPage)
+	case operandIndirectHighImmediate8:
+		return unsigned16(readUnsigned8(bus, &cpu.registers.pc), highPage)
+	case operandIndirectImmediate16:
+		return readUnsigned16(bus, &cpu.registers.pc)
+	default:
+		log.Panicf("operand %d is not an address", operand)
+		return 0
+	}
+}
+
+func (cpu *cpu) read8(bus *Bus, operand operand8) uint8 {
+	if register := cpu.registers.register8Ptr(operand); register != nil {
+		return *register
+	}
+	if operand == operandImmediate8 {
+		return readUnsigned8(bus, &cpu.registers.pc)
+	}
+	return bus.read(cpu.address8(bus, operand))
+}
+
+func (cpu *cpu) write8(bus *Bus, operand operand8, value uint8) {
+	if register := cpu.registers.register8Ptr(operand); register != nil {
+		*register = value
+		return
+	}
+	bus.write(cpu.address8(bus, operand), value)
+}
+
+// modify8 applies f to the operand. Memory operands are read and written at the same address.
+func (cpu *cpu) modify8(bus *Bus, operand operand8, f func(value uint8) uint8) {
+	if register := cpu.registers.register8Ptr(operand); register != nil {
+		*register = f(*register)
+		return
+	}
+	address := cpu.address8(bus, operand)
+	bus.write(address, f(bus.read(address)))
+}
+
+// register16Ptr returns the register behind the operand or nil if the operand is not a register.
+func (r *registers) register16Ptr(operand operand16) *uint16 {
+	switch operand {
+	case operandBC:
+		return &r.bc
+	case operandDE:
+		return &r.de
+	case operandHL:
+		return &r.hl
+	case operandSP:
+		return &r.sp
+	case operandAF:
+		return &r.af
+	default:
+		return nil
+	}
+}
+
+func (cpu *cpu) read16(bus *Bus, operand operand16) uint16 {
+	switch operand {
+	case operandImmediate16:
+		return readUnsigned16(bus, &cpu.registers.pc)
+	case operandIndirectWord16:
+		address := readUnsigned16(bus, &cpu.registers.pc)
+		return unsigned16(bus.read(address), bus.read(address+1))
+	default:
+		return *cpu.registers.register16Ptr(operand)
+	}
+}
+
+func (cpu *cpu) write16(bus *Bus, operand operand16, value uint16) {
+	switch operand {
+	case operandAF:
+		// the lower nibble of F is not backed by hardware and always reads as zero
+		cpu.registers.af = value & 0xFFF0
+	case operandIndirectWord16:
+		address := readUnsigned16(bus, &cpu.registers.pc)
+		msb, lsb := mostAndLeastSignificantByte(value)
+		bus.write(address, lsb)
+		bus.write(address+1, msb)
+	default:
+		register := cpu.registers.register16Ptr(operand)
+		if register == nil {
+			log.Panicf("operand %d is not writable", operand)
+		}
+		*register = value
+	}
+}
@@ -0,0 +1,57 @@
+package internal
+
+const lyAddress uint16 = 0xFF44 // LY: the line currently drawn by the ppu
+
+const (
+	dotsPerLine   = 456
+	linesPerFrame = 154
+	visibleLines  = 144
+	// cyclesPerFrame is the number of T-cycles of one frame (~59.7 frames per second)
+	cyclesPerFrame = dotsPerLine * linesPerFrame
+)
+
+// ppuMode is the mode of the ppu as reported in bits 1-0 of STAT.
+type ppuMode uint8
+
+const (
+	ppuModeHBlank ppuMode = iota
+	ppuModeVBlank
+	ppuModeOAMScan
+	ppuModeDrawing
+)
+
+// ppu keeps track of the timing of the picture processing unit. One dot equals one T-cycle in normal speed mode.
+type ppu struct {
+	dot    int // dot within the current line
+	ly     uint8
+	mode   ppuMode
+	vBlank bool // vBlank is set when the ppu enters mode 1 and is reset by the GameBoy requesting the interrupt
+}
+
+func (p *ppu) updateMode() {
+	switch {
+	case p.ly >= visibleLines:
+		if p.mode != ppuModeVBlank {
+			p.vBlank = true
+		}
+		p.mode = ppuModeVBlank
+	case p.dot < 80:
+		p.mode = ppuModeOAMScan
+	case p.dot < 80+172:
+		// the length of mode 3 depends on sprites and scrolling, 172 dots is the minimum
+		p.mode = ppuModeDrawing
+	default:
+		p.mode = ppuModeHBlank
+	}
+}
+
+// tick advances the ppu by the given number of T-cycles.
+func (p *ppu) tick(cycles int) {
+	p.dot += cycles
+	for p.dot >= dotsPerLine {
+		p.dot -= dotsPerLine
+		p.ly = uint8((int(p.ly) + 1) % linesPerFrame)
+		p.updateMode()
+	}
+	p.updateMode()
+}
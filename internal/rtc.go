@@ -0,0 +1,222 @@
+package internal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// rtc registers selected by writing 0x08-0x0C to 0x4000-0x5FFF of MBC3 and HuC3.
+const (
+	rtcSeconds = iota
+	rtcMinutes
+	rtcHours
+	rtcDaysLow
+	rtcDaysHigh // bit 0: bit 8 of the day counter, bit 6: halt, bit 7: day counter carry
+	rtcRegisterCount
+)
+
+// rtc is the real-time clock of MBC3 cartridges. By default it advances with the emulated time, so it runs in sync
+// with the game even if the emulation is paused or fast-forwarded. Alternatively it follows the wall clock of the host.
+// https://gbdev.io/pandocs/MBC3.html#the-clock-counter-registers
+type rtc struct {
+	seconds uint8 // 6 bits
+	minutes uint8 // 6 bits
+	hours   uint8 // 5 bits
+	days    uint16
+	halt    bool
+	carry   bool
+
+	latched      [rtcRegisterCount]uint8
+	latchPrimed  bool // latchPrimed is set after 0x00 is written to the latch register
+	subSecond    int  // T-cycles since the last increment of seconds
+	wallClock    bool
+	now          func() time.Time
+	lastWallTime time.Time
+}
+
+// rtcSaveSize is the size of the clock block appended to the RAM in .sav files, the format used by VBA-M and BGB: the
+// five clock registers and the five latched registers as 32-bit values followed by a 64-bit unix timestamp, all
+// little-endian. Older files use a 32-bit timestamp (44 bytes).
+const (
+	rtcSaveSize       = 48
+	rtcLegacySaveSize = 44
+)
+
+func newRTC(wallClock bool, now func() time.Time) *rtc {
+	r := &rtc{wallClock: wallClock, now: now}
+	if wallClock {
+		r.lastWallTime = now()
+	}
+	return r
+}
+
+// tick advances the clock by the given number of T-cycles in normal speed.
+func (r *rtc) tick(cycles int) {
+	if r.wallClock || r.halt {
+		return
+	}
+	r.subSecond += cycles
+	for r.subSecond >= clockSpeed {
+		r.subSecond -= clockSpeed
+		r.incrementSecond()
+	}
+}
+
+// syncWallClock advances the clock by the whole seconds of wall clock time elapsed since the last sync.
+func (r *rtc) syncWallClock() {
+	if !r.wallClock {
+		return
+	}
+	now := r.now()
+	elapsed := int64(now.Sub(r.lastWallTime) / time.Second)
+	r.lastWallTime = r.lastWallTime.Add(time.Duration(elapsed) * time.Second)
+	if r.halt {
+		return
+	}
+	r.advance(elapsed)
+}
+
+// advance increments the clock by the given number of seconds. Out of range counters are incremented second by second
+// until they overflow, from then on the counters are advanced arithmetically.
+func (r *rtc) advance(seconds int64) {
+	for ; seconds > 0 && !r.inRange(); seconds-- {
+		r.incrementSecond()
+	}
+	if seconds <= 0 {
+		return
+	}
+
+	total := int64(r.seconds) + seconds
+	r.seconds = uint8(total % 60)
+	total = int64(r.minutes) + total/60
+	r.minutes = uint8(total % 60)
+	total = int64(r.hours) + total/60
+	r.hours = uint8(total % 24)
+	days := int64(r.days) + total/24
+	if days >= 512 {
+		r.carry = true
+	}
+	r.days = uint16(days % 512)
+}
+
+// inRange reports whether the counters hold valid times, which keep wrapping at their limits when incremented.
+func (r *rtc) inRange() bool {
+	return r.seconds < 60 && r.minutes < 60 && r.hours < 24
+}
+
+// incrementSecond increments the counters the way the hardware does: every counter only wraps when it reaches its
+// limit exactly, out of range values written by the game count up until they overflow their bit width.
+func (r *rtc) incrementSecond() {
+	r.seconds = (r.seconds + 1) & 0b11_1111
+	if r.seconds != 60 {
+		return
+	}
+	r.seconds = 0
+	r.minutes = (r.minutes + 1) & 0b11_1111
+	if r.minutes != 60 {
+		return
+	}
+	r.minutes = 0
+	r.hours = (r.hours + 1) & 0b1_1111
+	if r.hours != 24 {
+		return
+	}
+	r.hours = 0
+	r.days++
+	if r.days == 512 {
+		r.days = 0
+		r.carry = true
+	}
+}
+
+func (r *rtc) registers() [rtcRegisterCount]uint8 {
+	daysHigh := uint8(r.days>>8) & 0b1
+	if r.halt {
+		daysHigh |= 0b0100_0000
+	}
+	if r.carry {
+		daysHigh |= 0b1000_0000
+	}
+	return [rtcRegisterCount]uint8{r.seconds, r.minutes, r.hours, uint8(r.days), daysHigh}
+}
+
+// writeLatch handles writes to 0x6000-0x7FFF, writing 0x00 followed by 0x01 copies the clock to the latched registers.
+func (r *rtc) writeLatch(value uint8) {
+	if r.latchPrimed && value == 0x01 {
+		r.syncWallClock()
+		r.latched = r.registers()
+	}
+	r.latchPrimed = value == 0x00
+}
+
+// read returns the latched value of the register.
+func (r *rtc) read(register int) uint8 {
+	return r.latched[register]
+}
+
+// write sets the register of the clock. The latched value is updated as well.
+func (r *rtc) write(register int, value uint8) {
+	r.syncWallClock()
+	switch register {
+	case rtcSeconds:
+		r.seconds = value & 0b11_1111
+		r.subSecond = 0
+	case rtcMinutes:
+		r.minutes = value & 0b11_1111
+	case rtcHours:
+		r.hours = value & 0b1_1111
+	case rtcDaysLow:
+		r.days = r.days&0x100 | uint16(value)
+	case rtcDaysHigh:
+		r.days = uint16(value&0b1)<<8 | r.days&0xFF
+		r.halt = value&0b0100_0000 != 0
+		r.carry = value&0b1000_0000 != 0
+	}
+	r.latched[register] = r.registers()[register]
+}
+
+// save returns the clock in the 48-byte .sav format.
+func (r *rtc) save() []byte {
+	r.syncWallClock()
+	block := make([]byte, rtcSaveSize)
+	for i, value := range r.registers() {
+		binary.LittleEndian.PutUint32(block[4*i:], uint32(value))
+	}
+	for i, value := range r.latched {
+		binary.LittleEndian.PutUint32(block[4*(rtcRegisterCount+i):], uint32(value))
+	}
+	binary.LittleEndian.PutUint64(block[8*rtcRegisterCount:], uint64(r.now().Unix()))
+	return block
+}
+
+// load restores the clock from the .sav format. With the wall clock, the time passed since the file was written is
+// added, with the emulated time the clock continues where it stopped. Timestamps that are zero or in the future, as
+// written by some emulators, are ignored and no time is added.
+func (r *rtc) load(block []byte) error {
+	var timestamp int64
+	switch len(block) {
+	case rtcSaveSize:
+		timestamp = int64(binary.LittleEndian.Uint64(block[8*rtcRegisterCount:]))
+	case rtcLegacySaveSize:
+		timestamp = int64(binary.LittleEndian.Uint32(block[8*rtcRegisterCount:]))
+	default:
+		return fmt.Errorf("invalid rtc block size: %d bytes", len(block))
+	}
+
+	for i := 0; i < rtcRegisterCount; i++ {
+		r.write(i, uint8(binary.LittleEndian.Uint32(block[4*i:])))
+	}
+	for i := range r.latched {
+		r.latched[i] = uint8(binary.LittleEndian.Uint32(block[4*(rtcRegisterCount+i):]))
+	}
+
+	if r.wallClock {
+		r.lastWallTime = r.now()
+		if saved := time.Unix(timestamp, 0); timestamp > 0 && saved.Before(r.lastWallTime) {
+			r.lastWallTime = saved
+		}
+		r.syncWallClock()
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// saveFlushInterval is the number of frames (about one second) between checks whether the save RAM needs to be
+// written to the .sav file.
+const saveFlushInterval = 60
+
+// batteryCartridge is implemented by cartridges whose RAM can be battery-backed.
+type batteryCartridge interface {
+	// saveRAM returns the RAM persisted in the .sav file. The returned slice is the RAM itself, not a copy.
+	saveRAM() []byte
+}
+
+// rtcCartridge is implemented by cartridges with a real-time clock persisted after the RAM in the .sav file.
+type rtcCartridge interface {
+	// saveRTC returns the clock in the 48-byte format, nil if the cartridge has no clock.
+	saveRTC() []byte
+	loadRTC(block []byte) error
+}
+
+// batteryCartridgeTypes are the cartridge types with a battery.
+var batteryCartridgeTypes = map[byte]bool{
+	0x03: true, 0x06: true, 0x09: true, 0x0D: true, 0x0F: true, 0x10: true, 0x13: true, 0x1B: true, 0x1E: true,
+	0x20: true, 0x22: true, 0xFD: true, 0xFE: true, 0xFF: true,
+}
+
+// hasBattery reports whether the cartridge keeps its RAM while switched off.
+func hasBattery(rom []byte) bool {
+	if len(rom) <= cartridgeTypeAddress {
+		return false
+	}
+	if padded := PadROM(rom); isMMM01(padded) {
+		return batteryCartridgeTypes[padded[len(padded)-mmm01MenuSize+cartridgeTypeAddress]]
+	}
+	return batteryCartridgeTypes[rom[cartridgeTypeAddress]]
+}
+
+// SavePath returns the path of the .sav file next to the rom.
+func SavePath(romPath string) string {
+	return strings.TrimSuffix(romPath, filepath.Ext(romPath)) + ".sav"
+}
+
+// saveData returns the contents of the .sav file: the raw RAM followed by the clock, if any.
+func saveData(cartridge batteryCartridge) []byte {
+	data := bytes.Clone(cartridge.saveRAM())
+	if clock, ok := cartridge.(rtcCartridge); ok {
+		data = append(data, clock.saveRTC()...)
+	}
+	return data
+}
+
+// loadSave restores the RAM and clock of the cartridge from the .sav file. A missing file is not an error.
+func loadSave(path string, cartridge batteryCartridge) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	ram := cartridge.saveRAM()
+	n := copy(ram, data)
+	if clock, ok := cartridge.(rtcCartridge); ok && len(data) > n {
+		if err := clock.loadRTC(data[n:]); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// writeSave writes the .sav file through a temporary file, so a crash while writing doesn't corrupt the save.
+func writeSave(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// FlushSave writes the battery-backed RAM to the .sav file if it changed since the last flush. Cartridges with a
+// clock are always written when force is set, as the clock advances without the RAM changing.
+func (gb *GameBoy) FlushSave(force bool) error {
+	cartridge, ok := gb.bus.cartridge.(batteryCartridge)
+	if !ok || gb.savePath == "" {
+		return nil
+	}
+
+	data := saveData(cartridge)
+	if !force && bytes.Equal(data[:len(cartridge.saveRAM())], gb.savedRAM) {
+		return nil
+	}
+	if err := writeSave(gb.savePath, data); err != nil {
+		return err
+	}
+	gb.savedRAM = bytes.Clone(cartridge.saveRAM())
+	return nil
+}
@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"encoding/binary"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSavePath(t *testing.T) {
+	assert.Equal(t, "/roms/game.sav", SavePath("/roms/game.gb"))
+	assert.Equal(t, "game.sav", SavePath("game"))
+}
+
+func writeTestROM(t *testing.T, rom []byte) string {
+	path := filepath.Join(t.TempDir(), "game.gbc")
+	assert.NoError(t, os.WriteFile(path, rom, 0644))
+	return path
+}
+
+func TestSaveRoundTrip(t *testing.T) {
+	path := writeTestROM(t, newTestROM(0x1B, 0x02, 4))
+
+	gb := NewGameBoy()
+	assert.NoError(t, gb.LoadCartridge(path))
+	gb.bus.write(0x0000, 0x0A)
+	gb.bus.write(0xA123, 0x42)
+	assert.NoError(t, gb.FlushSave(false))
+
+	data, err := os.ReadFile(SavePath(path))
+	assert.NoError(t, err)
+	assert.Len(t, data, 8*1024)
+	assert.Equal(t, uint8(0x42), data[0x123])
+
+	gb = NewGameBoy()
+	assert.NoError(t, gb.LoadCartridge(path))
+	gb.bus.write(0x0000, 0x0A)
+	assert.Equal(t, uint8(0x42), gb.bus.read(0xA123))
+}
+
+func TestSaveOnlyWhenChanged(t *testing.T) {
+	path := writeTestROM(t, newTestROM(0x03, 0x02, 4))
+
+	gb := NewGameBoy()
+	assert.NoError(t, gb.LoadCartridge(path))
+	assert.NoError(t, gb.FlushSave(false))
+	assert.NoFileExists(t, SavePath(path))
+
+	assert.NoError(t, gb.FlushSave(true))
+	assert.FileExists(t, SavePath(path))
+}
+
+func TestSaveWithoutBattery(t *testing.T) {
+	path := writeTestROM(t, newTestROM(0x02, 0x02, 4))
+
+	gb := NewGameBoy()
+	assert.NoError(t, gb.LoadCartridge(path))
+	assert.NoError(t, gb.FlushSave(true))
+	assert.NoFileExists(t, SavePath(path))
+}
+
+func TestSaveRTC(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	clock := func(options *cartridgeOptions) {
+		options.now = func() time.Time { return now }
+	}
+	path := writeTestROM(t, newTestROM(0x10, 0x03, 4))
+
+	gb := NewGameBoy()
+	assert.NoError(t, gb.LoadCartridge(path, WithWallClockRTC(), clock))
+	rtc := gb.bus.cartridge.(*mbc3).rtc
+	rtc.write(rtcMinutes, 10)
+	assert.NoError(t, gb.FlushSave(true))
+
+	data, err := os.ReadFile(SavePath(path))
+	assert.NoError(t, err)
+	assert.Len(t, data, 32*1024+rtcSaveSize)
+
+	// an hour later
+	now = now.Add(time.Hour)
+	gb = NewGameBoy()
+	assert.NoError(t, gb.LoadCartridge(path, WithWallClockRTC(), clock))
+	rtc = gb.bus.cartridge.(*mbc3).rtc
+	assert.Equal(t, uint8(10), rtc.minutes)
+	assert.Equal(t, uint8(1), rtc.hours)
+}
+
+func TestHuC3SaveRTC(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	clock := func() time.Time { return now }
+	cartridge := newHuC3(nil, nil, clock)
+	cartridge.minutes = 13*60 + 37
+	cartridge.days = 0x123
+
+	block := cartridge.saveRTC()
+	assert.Equal(t, uint64(now.Unix()), binary.LittleEndian.Uint64(block[8*rtcRegisterCount:]))
+
+	restored := newHuC3(nil, nil, clock)
+	assert.NoError(t, restored.loadRTC(block))
+	assert.Equal(t, cartridge.minutes, restored.minutes)
+	assert.Equal(t, cartridge.days, restored.days)
+}
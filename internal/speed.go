@@ -0,0 +1,35 @@
+package internal
+
+const key1Address uint16 = 0xFF4D // KEY1: CGB speed switch
+
+// speed holds the CGB KEY1 register. A speed switch is armed by writing bit 0 and performed by the next STOP. In double
+// speed mode the cpu and the timer run at twice the clock, while the ppu and apu keep their speed.
+type speed struct {
+	double bool
+	armed  bool
+}
+
+func (s *speed) read() uint8 {
+	value := uint8(0b0111_1110)
+	if s.double {
+		value |= 0b1000_0000
+	}
+	if s.armed {
+		value |= 0b0000_0001
+	}
+	return value
+}
+
+func (s *speed) write(value uint8) {
+	s.armed = value&0b1 != 0
+}
+
+// switchSpeed toggles the speed if a switch is armed and reports whether it did.
+func (s *speed) switchSpeed() bool {
+	if !s.armed {
+		return false
+	}
+	s.armed = false
+	s.double = !s.double
+	return true
+}
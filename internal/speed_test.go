@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStopWithoutSpeedSwitch(t *testing.T) {
+	// STOP; INC A
+	gb := newTestGameBoy([]byte{0x10, 0x00, 0x3C})
+	gb.step()
+	assert.True(t, gb.cpu.stopped)
+
+	gb.step()
+	assert.Equal(t, uint8(0), gb.cpu.registers.a())
+	assert.Equal(t, 0, gb.ppu.dot)
+
+	gb.interrupts.request(joypadInterrupt)
+	gb.step()
+	assert.Equal(t, uint8(1), gb.cpu.registers.a())
+}
+
+func TestSpeedSwitch(t *testing.T) {
+	// LD A, 0x01; LDH [KEY1], A; STOP; NOP
+	gb := newTestGameBoy([]byte{0x3E, 0x01, 0xE0, 0x4D, 0x10, 0x00, 0x00})
+	gb.bus.speed = &gb.speed
+
+	for i := 0; i < 3; i++ {
+		gb.step()
+	}
+	assert.False(t, gb.cpu.stopped)
+	assert.Equal(t, uint8(0b1111_1110), gb.bus.read(key1Address))
+
+	// in double speed the ppu advances by half of the cpu cycles
+	dot := gb.ppu.dot
+	gb.step()
+	assert.Equal(t, dot+2, gb.ppu.dot)
+}
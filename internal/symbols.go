@@ -0,0 +1,195 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// hardwareRegisters names the I/O registers like hardware.inc does.
+// https://github.com/gbdev/hardware.inc
+var hardwareRegisters = map[uint16]string{
+	0xFF00: "rP1",
+	0xFF01: "rSB",
+	0xFF02: "rSC",
+	0xFF04: "rDIV",
+	0xFF05: "rTIMA",
+	0xFF06: "rTMA",
+	0xFF07: "rTAC",
+	0xFF0F: "rIF",
+	0xFF10: "rNR10",
+	0xFF11: "rNR11",
+	0xFF12: "rNR12",
+	0xFF13: "rNR13",
+	0xFF14: "rNR14",
+	0xFF16: "rNR21",
+	0xFF17: "rNR22",
+	0xFF18: "rNR23",
+	0xFF19: "rNR24",
+	0xFF1A: "rNR30",
+	0xFF1B: "rNR31",
+	0xFF1C: "rNR32",
+	0xFF1D: "rNR33",
+	0xFF1E: "rNR34",
+	0xFF20: "rNR41",
+	0xFF21: "rNR42",
+	0xFF22: "rNR43",
+	0xFF23: "rNR44",
+	0xFF24: "rNR50",
+	0xFF25: "rNR51",
+	0xFF26: "rNR52",
+	0xFF40: "rLCDC",
+	0xFF41: "rSTAT",
+	0xFF42: "rSCY",
+	0xFF43: "rSCX",
+	0xFF44: "rLY",
+	0xFF45: "rLYC",
+	0xFF46: "rDMA",
+	0xFF47: "rBGP",
+	0xFF48: "rOBP0",
+	0xFF49: "rOBP1",
+	0xFF4A: "rWY",
+	0xFF4B: "rWX",
+	0xFF4D: "rKEY1",
+	0xFF4F: "rVBK",
+	0xFF51: "rHDMA1",
+	0xFF52: "rHDMA2",
+	0xFF53: "rHDMA3",
+	0xFF54: "rHDMA4",
+	0xFF55: "rHDMA5",
+	0xFF56: "rRP",
+	0xFF68: "rBCPS",
+	0xFF69: "rBCPD",
+	0xFF6A: "rOCPS",
+	0xFF6B: "rOCPD",
+	0xFF70: "rSVBK",
+	0xFFFF: "rIE",
+}
+
+// vectorNames names the addresses the hardware starts executing cartridge code at.
+var vectorNames = map[uint16]string{
+	0x0000: "Rst00",
+	0x0008: "Rst08",
+	0x0010: "Rst10",
+	0x0018: "Rst18",
+	0x0020: "Rst20",
+	0x0028: "Rst28",
+	0x0030: "Rst30",
+	0x0038: "Rst38",
+	0x0040: "VBlankInterrupt",
+	0x0048: "LCDInterrupt",
+	0x0050: "TimerInterrupt",
+	0x0058: "SerialInterrupt",
+	0x0060: "JoypadInterrupt",
+	0x0100: "EntryPoint",
+}
+
+// Symbols names locations of the rom, the RAM and the I/O registers.
+type Symbols struct {
+	names map[BankAddress]string
+	// banks lists the banks an address has a name in, to look up addresses whose bank is not known
+	banks map[uint16][]uint16
+}
+
+// NewSymbols returns symbols with the names of the I/O registers and the vectors.
+func NewSymbols() *Symbols {
+	s := &Symbols{names: make(map[BankAddress]string), banks: make(map[uint16][]uint16)}
+	for address, name := range hardwareRegisters {
+		s.Add(BankAddress{0, address}, name)
+	}
+	for address, name := range vectorNames {
+		s.Add(BankAddress{0, address}, name)
+	}
+	return s
+}
+
+// LoadSymbols returns the symbols of NewSymbols together with the symbols of a .sym file.
+func LoadSymbols(path string) (*Symbols, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	s := NewSymbols()
+	err = s.ReadSym(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Add names the location, replacing an existing name.
+func (s *Symbols) Add(location BankAddress, name string) {
+	if _, ok := s.names[location]; !ok {
+		s.banks[location.Address] = append(s.banks[location.Address], location.Bank)
+	}
+	s.names[location] = name
+}
+
+// ReadSym adds the symbols of a .sym file as written by rgblink and no$gmb: one "bank:address name" per line, with
+// comments starting with a semicolon.
+func (s *Symbols) ReadSym(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), ";")
+		text = strings.TrimSpace(text)
+		// no$gmb files may contain section headers like [labels]
+		if text == "" || strings.HasPrefix(text, "[") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return fmt.Errorf("line %d: invalid symbol %q", line, text)
+		}
+		bankText, addressText, ok := strings.Cut(fields[0], ":")
+		bank, bankErr := strconv.ParseUint(bankText, 16, 16)
+		address, addressErr := strconv.ParseUint(addressText, 16, 16)
+		if !ok || bankErr != nil || addressErr != nil {
+			return fmt.Errorf("line %d: invalid location %q", line, fields[0])
+		}
+		s.Add(BankAddress{uint16(bank), uint16(address)}, fields[1])
+	}
+	return scanner.Err()
+}
+
+// Lookup returns the name of the location. As the RAM bank of an access is usually not known, RAM addresses
+// (0x8000-0xFFFF) without a name in the bank are resolved if exactly one bank has a name for them.
+func (s *Symbols) Lookup(location BankAddress) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	if name, ok := s.names[location]; ok {
+		return name, true
+	}
+	if location.Address >= 0x8000 {
+		return s.LookupAddress(location.Address)
+	}
+	return "", false
+}
+
+// LookupAddress returns the name of an address whose bank is not known. Addresses outside 0x0000-0x3FFF are only
+// resolved if exactly one bank has a name for them.
+func (s *Symbols) LookupAddress(address uint16) (string, bool) {
+	if s == nil {
+		return "", false
+	}
+	if address < 0x4000 {
+		name, ok := s.names[BankAddress{0, address}]
+		return name, ok
+	}
+	if banks := s.banks[address]; len(banks) == 1 {
+		return s.names[BankAddress{banks[0], address}], true
+	}
+	return "", false
+}
+
+// FormatInstruction renders the instruction like the package level FormatInstruction, with the names of the target
+// and memory operands instead of their addresses.
+func (s *Symbols) FormatInstruction(i Instruction) string {
+	return formatInstruction(i, s)
+}
@@ -0,0 +1,109 @@
+package internal
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestReadSym(t *testing.T) {
+	symbols := NewSymbols()
+	err := symbols.ReadSym(strings.NewReader(`; File generated by rgblink
+[labels]
+00:0150 Main
+05:4123 FarFunction
+05:4200 Shared
+06:4200 Shared.other
+00:C000 wCounter
+01:D000 wBanked
+`))
+	assert.NoError(t, err)
+
+	lookup := func(bank, address uint16) string {
+		name, _ := symbols.Lookup(BankAddress{bank, address})
+		return name
+	}
+	assert.Equal(t, "Main", lookup(0, 0x0150))
+	assert.Equal(t, "FarFunction", lookup(5, 0x4123))
+	assert.Equal(t, "", lookup(4, 0x4123))
+	assert.Equal(t, "wCounter", lookup(0, 0xC000))
+	// the RAM bank of an access is not known
+	assert.Equal(t, "wBanked", lookup(0, 0xD000))
+	assert.Equal(t, "rLY", lookup(0, 0xFF44))
+	assert.Equal(t, "VBlankInterrupt", lookup(0, 0x0040))
+
+	name, ok := symbols.LookupAddress(0x4123)
+	assert.True(t, ok)
+	assert.Equal(t, "FarFunction", name)
+	_, ok = symbols.LookupAddress(0x4200)
+	assert.False(t, ok)
+
+	assert.ErrorContains(t, symbols.ReadSym(strings.NewReader("00:0150 Main\nMain\n")), "line 2")
+	assert.ErrorContains(t, symbols.ReadSym(strings.NewReader("0150 Main\n")), "line 1")
+}
+
+func TestTraceSymbolBank(t *testing.T) {
+	symbols := NewSymbols()
+	symbols.Add(BankAddress{5, 0x4200}, "InBank5")
+	symbols.Add(BankAddress{6, 0x4200}, "InBank6")
+	symbols.Add(BankAddress{6, 0x4300}, "OnlyInBank6")
+
+	cartridge, err := NewCartridge(newTestROM(0x01, 0x00, 8))
+	assert.NoError(t, err)
+	gb := NewGameBoy()
+	gb.bus.cartridge = cartridge
+	gb.SetSymbols(symbols)
+
+	cartridge.WriteROM(0x2000, 0x05)
+	name, _ := gb.cpu.symbol(&gb.bus, 0x4200)
+	assert.Equal(t, "InBank5", name)
+	// names of other banks are not used
+	_, ok := gb.cpu.symbol(&gb.bus, 0x4300)
+	assert.False(t, ok)
+
+	cartridge.WriteROM(0x2000, 0x06)
+	name, _ = gb.cpu.symbol(&gb.bus, 0x4200)
+	assert.Equal(t, "InBank6", name)
+	name, _ = gb.cpu.symbol(&gb.bus, 0x0040)
+	assert.Equal(t, "VBlankInterrupt", name)
+
+	// MBC6 doesn't report a 16 KiB bank
+	gb.bus.cartridge = newMBC6(newTestROM(0x20, 0x00, 8))
+	_, ok = gb.cpu.symbol(&gb.bus, 0x4300)
+	assert.False(t, ok)
+}
+
+func TestFormatInstructionSymbols(t *testing.T) {
+	opcodes, err := ParseOpcodes()
+	assert.NoError(t, err)
+
+	symbols := NewSymbols()
+	symbols.Add(BankAddress{0, 0x0150}, "Main")
+	symbols.Add(BankAddress{0, 0xC31C}, "wScore")
+
+	data := []struct {
+		code        []byte
+		instruction string
+	}{
+		{[]byte{0xF0, 0x44}, "LDH A, [rLY]"},
+		{[]byte{0xEA, 0x1C, 0xC3}, "LD [wScore], A"},
+		{[]byte{0xEA, 0x1D, 0xC3}, "LD [0xC31D], A"},
+		{[]byte{0xCD, 0x50, 0x01}, "CALL Main"},
+		{[]byte{0x18, 0x4E}, "JR Main"},
+		{[]byte{0xC3, 0x40, 0x00}, "JP VBlankInterrupt"},
+	}
+	for _, d := range data {
+		i := decodeInstruction(d.code, 0, 0x0100, opcodes)
+		assert.Equal(t, d.instruction, symbols.FormatInstruction(i))
+	}
+
+	var out bytes.Buffer
+	rom := make([]byte, 0x0200)
+	copy(rom[0x0100:], []byte{0xC3, 0x50, 0x01})
+	copy(rom[0x0150:], []byte{0x18, 0xFE})
+	instructions := DisassembleRecursive(rom, []uint16{entryPointAddress}, opcodes)
+	assert.NoError(t, WriteRGBDS(&out, instructions, RGBDSOptions{Symbols: symbols}))
+	assert.Contains(t, out.String(), "EntryPoint:\n\tjp Main\n")
+	assert.Contains(t, out.String(), "Main:\n\tjr Main\n")
+}
@@ -0,0 +1,102 @@
+package internal
+
+// TAMA5 registers, selected by writing to 0xA001 and written through 0xA000.
+const (
+	tama5ROMBankLow  = 0x0
+	tama5ROMBankHigh = 0x1
+	tama5DataLow     = 0x4
+	tama5DataHigh    = 0x5
+	tama5AddressHigh = 0x6 // bit 0: bit 4 of the address, bits 3-1: command
+	tama5AddressLow  = 0x7 // writing the lower address bits executes the command
+	tama5Ready       = 0xA
+	tama5ReadLow     = 0xC
+	tama5ReadHigh    = 0xD
+)
+
+// TAMA5 commands in bits 3-1 of tama5AddressHigh.
+const (
+	tama5CommandWrite = 0x0
+	tama5CommandRead  = 0x1
+)
+
+const tama5RAMSize = 32
+
+// tama5 is the TAMA5 controller by Bandai (Game de Hakken!! Tamagotchi 3). It is only accessed through two registers
+// at 0xA000 and 0xA001 and has 32 bytes of RAM. The TAMA6 microcontroller and the RTC are stubs.
+type tama5 struct {
+	rom []byte
+	ram [tama5RAMSize]byte
+
+	register  uint8
+	registers [16]uint8
+	readValue uint8
+}
+
+func newTAMA5(rom []byte) *tama5 {
+	return &tama5{rom: rom}
+}
+
+func (m *tama5) romBank() int {
+	return int(m.registers[tama5ROMBankHigh]&0b1)<<4 | int(m.registers[tama5ROMBankLow])
+}
+
+func (m *tama5) mappedROMBank() int {
+	return wrapROMBank(m.rom, m.romBank())
+}
+
+func (m *tama5) saveRAM() []byte {
+	return m.ram[:]
+}
+
+func (m *tama5) ReadROM(address uint16) uint8 {
+	if address < 0x4000 {
+		return romBank(m.rom, 0, address)
+	}
+	return romBank(m.rom, m.romBank(), address-0x4000)
+}
+
+func (m *tama5) WriteROM(address uint16, value uint8) {
+	// no registers in the ROM area
+}
+
+func (m *tama5) ReadRAM(address uint16) uint8 {
+	if address&0x1FFF > 1 {
+		return openBus
+	}
+	if address&1 == 1 {
+		return 0xFF
+	}
+	switch m.register {
+	case tama5Ready:
+		return 0xF1
+	case tama5ReadLow:
+		return 0xF0 | m.readValue&0x0F
+	case tama5ReadHigh:
+		return 0xF0 | m.readValue>>4
+	default:
+		return openBus
+	}
+}
+
+func (m *tama5) WriteRAM(address uint16, value uint8) {
+	if address&0x1FFF > 1 {
+		return
+	}
+	if address&1 == 1 {
+		m.register = value & 0x0F
+		return
+	}
+
+	m.registers[m.register] = value & 0x0F
+	if m.register != tama5AddressLow {
+		return
+	}
+
+	ramAddress := (m.registers[tama5AddressHigh]&0b1)<<4 | m.registers[tama5AddressLow]
+	switch m.registers[tama5AddressHigh] >> 1 {
+	case tama5CommandWrite:
+		m.ram[ramAddress] = m.registers[tama5DataHigh]<<4 | m.registers[tama5DataLow]
+	case tama5CommandRead:
+		m.readValue = m.ram[ramAddress]
+	}
+}
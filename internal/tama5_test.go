@@ -0,0 +1,37 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func tama5Write(cartridge Cartridge, register, value uint8) {
+	cartridge.WriteRAM(0xA001, register)
+	cartridge.WriteRAM(0xA000, value)
+}
+
+func tama5Read(cartridge Cartridge, register uint8) uint8 {
+	cartridge.WriteRAM(0xA001, register)
+	return cartridge.ReadRAM(0xA000)
+}
+
+func TestTAMA5(t *testing.T) {
+	cartridge, err := NewCartridge(newTestROM(0xFD, 0x00, 32))
+	assert.NoError(t, err)
+
+	tama5Write(cartridge, tama5ROMBankLow, 0x2)
+	tama5Write(cartridge, tama5ROMBankHigh, 0x1)
+	assert.Equal(t, uint8(0x12), cartridge.ReadROM(0x4000))
+	assert.Equal(t, uint8(0xF1), tama5Read(cartridge, tama5Ready))
+
+	// write 0xA5 to RAM address 0x13
+	tama5Write(cartridge, tama5DataLow, 0x5)
+	tama5Write(cartridge, tama5DataHigh, 0xA)
+	tama5Write(cartridge, tama5AddressHigh, tama5CommandWrite<<1|1)
+	tama5Write(cartridge, tama5AddressLow, 0x3)
+
+	tama5Write(cartridge, tama5AddressHigh, tama5CommandRead<<1|1)
+	tama5Write(cartridge, tama5AddressLow, 0x3)
+	assert.Equal(t, uint8(0xF5), tama5Read(cartridge, tama5ReadLow))
+	assert.Equal(t, uint8(0xFA), tama5Read(cartridge, tama5ReadHigh))
+}
@@ -0,0 +1,84 @@
+package internal
+
+const (
+	divAddress  uint16 = 0xFF04 // DIV: divider register, upper byte of the internal counter
+	timaAddress uint16 = 0xFF05 // TIMA: timer counter
+	tmaAddress  uint16 = 0xFF06 // TMA: timer modulo, reloaded into TIMA on overflow
+	tacAddress  uint16 = 0xFF07 // TAC: timer control
+)
+
+// timer implements DIV, TIMA, TMA and TAC. DIV is the upper byte of a 16-bit counter that is incremented every
+// T-cycle. TIMA is incremented on the falling edge of the counter bit selected by TAC, which is why writes to DIV or
+// TAC can increment TIMA as well.
+type timer struct {
+	counter  uint16
+	tima     uint8
+	tma      uint8
+	tac      uint8
+	overflow bool // overflow is set when TIMA overflowed and is reset by the GameBoy requesting the interrupt
+}
+
+// timerCounterBits maps the clock select of TAC (bits 1-0) to the bit of the internal counter driving TIMA.
+var timerCounterBits = [4]uint{9, 3, 5, 7}
+
+// signal returns the input of the falling edge detector driving TIMA.
+func (t *timer) signal() bool {
+	enabled := t.tac&0b100 != 0
+	return enabled && t.counter&(1<<timerCounterBits[t.tac&0b11]) != 0
+}
+
+func (t *timer) incrementTima() {
+	t.tima++
+	if t.tima == 0 {
+		t.tima = t.tma
+		t.overflow = true
+	}
+}
+
+// setCounter changes the internal counter and increments TIMA on a falling edge of the selected bit.
+func (t *timer) setCounter(counter uint16) {
+	before := t.signal()
+	t.counter = counter
+	if before && !t.signal() {
+		t.incrementTima()
+	}
+}
+
+// tick advances the timer by the given number of T-cycles.
+func (t *timer) tick(cycles int) {
+	for i := 0; i < cycles; i++ {
+		t.setCounter(t.counter + 1)
+	}
+}
+
+func (t *timer) read(address uint16) uint8 {
+	switch address {
+	case divAddress:
+		return highPart(t.counter)
+	case timaAddress:
+		return t.tima
+	case tmaAddress:
+		return t.tma
+	default:
+		// the upper five bits of TAC are unused and read as 1
+		return t.tac | 0b1111_1000
+	}
+}
+
+func (t *timer) write(address uint16, value uint8) {
+	switch address {
+	case divAddress:
+		// any write resets the whole internal counter
+		t.setCounter(0)
+	case timaAddress:
+		t.tima = value
+	case tmaAddress:
+		t.tma = value
+	default:
+		before := t.signal()
+		t.tac = value & 0b111
+		if before && !t.signal() {
+			t.incrementTima()
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTimerDivider(t *testing.T) {
+	var timer timer
+	timer.tick(255)
+	assert.Equal(t, uint8(0), timer.read(divAddress))
+	timer.tick(1)
+	assert.Equal(t, uint8(1), timer.read(divAddress))
+
+	timer.write(divAddress, 0x42)
+	assert.Equal(t, uint8(0), timer.read(divAddress))
+}
+
+func TestTimerCounter(t *testing.T) {
+	var timer timer
+	// enabled, increment every 16 T-cycles
+	timer.write(tacAddress, 0b101)
+	timer.tick(16 * 3)
+	assert.Equal(t, uint8(3), timer.read(timaAddress))
+	assert.Equal(t, uint8(0b1111_1101), timer.read(tacAddress))
+}
+
+func TestTimerOverflowReloadsModulo(t *testing.T) {
+	var timer timer
+	timer.write(tmaAddress, 0xF0)
+	timer.write(timaAddress, 0xFF)
+	timer.write(tacAddress, 0b101)
+	timer.tick(16)
+	assert.Equal(t, uint8(0xF0), timer.read(timaAddress))
+	assert.True(t, timer.overflow)
+}
+
+func TestTimerDividerResetFallingEdge(t *testing.T) {
+	var timer timer
+	timer.write(tacAddress, 0b101)
+	timer.tick(8)
+	// bit 3 of the counter is set, resetting DIV causes a falling edge
+	timer.write(divAddress, 0)
+	assert.Equal(t, uint8(1), timer.read(timaAddress))
+}